@@ -0,0 +1,212 @@
+package caption
+
+import (
+	"math"
+	"strings"
+)
+
+const (
+	alignMatch byte = iota
+	alignSubstitution
+	alignDeletion
+	alignInsertion
+)
+
+const (
+	wordAlignBand = 100
+	charAlignBand = 500
+)
+
+type alignStep struct {
+	op  byte
+	hyp int
+	ref int
+}
+
+type CueAccuracy struct {
+	Index         int
+	Cue           SubtitleText
+	Reference     string
+	Substitutions int
+	Deletions     int
+	Insertions    int
+	WER           float64
+}
+
+type AccuracyReport struct {
+	ReferenceWords int
+	Substitutions  int
+	Deletions      int
+	Insertions     int
+	WER            float64
+	CER            float64
+	Cues           []CueAccuracy
+}
+
+func align[T comparable](hyp, ref []T, band int) []alignStep {
+	n, m := len(hyp), len(ref)
+	if n == 0 || m == 0 {
+		steps := make([]alignStep, 0, n+m)
+		for j := range m {
+			steps = append(steps, alignStep{op: alignDeletion, hyp: -1, ref: j})
+		}
+		for i := range n {
+			steps = append(steps, alignStep{op: alignInsertion, hyp: i, ref: -1})
+		}
+		return steps
+	}
+	width := band + (max(n, m)-min(n, m))/4 + m/n + 1
+	bounds := func(i int) (int, int) {
+		center := i * m / n
+		return max(0, center-width), min(m, center+width)
+	}
+
+	const inf = math.MaxInt32
+	prev := make([]int, m+1)
+	curr := make([]int, m+1)
+	ops := make([][]byte, n+1)
+	lo, hi := bounds(0)
+	ops[0] = make([]byte, hi-lo+1)
+	for j := range prev {
+		prev[j] = inf
+	}
+	for j := lo; j <= hi; j++ {
+		prev[j] = j
+		ops[0][j-lo] = alignDeletion
+	}
+	for i := 1; i <= n; i++ {
+		prevLo, prevHi := lo, hi
+		lo, hi = bounds(i)
+		ops[i] = make([]byte, hi-lo+1)
+		for j := lo; j <= hi; j++ {
+			best, op := inf, alignInsertion
+			if j >= prevLo && j <= prevHi && prev[j] < inf {
+				best = prev[j] + 1
+			}
+			if j > 0 && j-1 >= prevLo && j-1 <= prevHi && prev[j-1] < inf {
+				cost, diag := 1, alignSubstitution
+				if hyp[i-1] == ref[j-1] {
+					cost, diag = 0, alignMatch
+				}
+				if prev[j-1]+cost <= best {
+					best, op = prev[j-1]+cost, diag
+				}
+			}
+			if j > lo && curr[j-1] < inf && curr[j-1]+1 < best {
+				best, op = curr[j-1]+1, alignDeletion
+			}
+			curr[j], ops[i][j-lo] = best, op
+		}
+		prev, curr = curr, prev
+	}
+
+	var steps []alignStep
+	for i, j := n, m; i > 0 || j > 0; {
+		op := alignDeletion
+		if i > 0 {
+			rowLo, _ := bounds(i)
+			op = ops[i][j-rowLo]
+		}
+		steps = append(steps, alignStep{op: op, hyp: i - 1, ref: j - 1})
+		switch op {
+		case alignMatch, alignSubstitution:
+			i, j = i-1, j-1
+		case alignDeletion:
+			j--
+		case alignInsertion:
+			i--
+		}
+	}
+	for l, r := 0, len(steps)-1; l < r; l, r = l+1, r-1 {
+		steps[l], steps[r] = steps[r], steps[l]
+	}
+	return steps
+}
+
+func editDistance(steps []alignStep) int {
+	distance := 0
+	for _, step := range steps {
+		if step.op != alignMatch {
+			distance++
+		}
+	}
+	return distance
+}
+
+func (c *Caption) Accuracy(reference string) AccuracyReport {
+	subs := c.GetSubtitleText()
+	var hyp []quoteWord
+	for i, sub := range subs {
+		for _, word := range strings.Fields(sub.Text) {
+			if norm := normalizeWord(word); norm != "" {
+				hyp = append(hyp, quoteWord{text: word, norm: norm, cue: i})
+			}
+		}
+	}
+	ref := quoteWords(reference)
+	hypNorm := make([]string, len(hyp))
+	for i, word := range hyp {
+		hypNorm[i] = word.norm
+	}
+
+	report := AccuracyReport{ReferenceWords: len(ref)}
+	cues := make([]CueAccuracy, len(subs))
+	refWords := make([][]string, len(subs))
+	for i, sub := range subs {
+		cues[i] = CueAccuracy{Index: i, Cue: sub}
+	}
+	cueFor := func(step alignStep) int {
+		switch {
+		case len(hyp) == 0:
+			return -1
+		case step.hyp >= 0:
+			return hyp[step.hyp].cue
+		default:
+			return hyp[0].cue
+		}
+	}
+	for _, step := range align(hypNorm, ref, wordAlignBand) {
+		cue := cueFor(step)
+		if step.op != alignInsertion && cue >= 0 {
+			refWords[cue] = append(refWords[cue], ref[step.ref])
+		}
+		switch step.op {
+		case alignSubstitution:
+			report.Substitutions++
+			if cue >= 0 {
+				cues[cue].Substitutions++
+			}
+		case alignDeletion:
+			report.Deletions++
+			if cue >= 0 {
+				cues[cue].Deletions++
+			}
+		case alignInsertion:
+			report.Insertions++
+			cues[cue].Insertions++
+		}
+	}
+
+	for i := range cues {
+		cues[i].Reference = strings.Join(refWords[i], " ")
+		errors := cues[i].Substitutions + cues[i].Deletions + cues[i].Insertions
+		cues[i].WER = errorRate(errors, len(refWords[i]))
+	}
+	report.Cues = cues
+	report.WER = errorRate(report.Substitutions+report.Deletions+report.Insertions, len(ref))
+
+	hypChars := []rune(strings.Join(hypNorm, " "))
+	refChars := []rune(strings.Join(ref, " "))
+	report.CER = errorRate(editDistance(align(hypChars, refChars, charAlignBand)), len(refChars))
+	return report
+}
+
+func errorRate(errors, total int) float64 {
+	if total == 0 {
+		if errors == 0 {
+			return 0
+		}
+		return 1
+	}
+	return float64(errors) / float64(total)
+}
@@ -0,0 +1,94 @@
+package caption
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func levenshtein(a, b []string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr := make([]int, len(b)+1)
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev = curr
+	}
+	return prev[len(b)]
+}
+
+func TestAlignEdgeCases(t *testing.T) {
+	long := strings.Fields(strings.Repeat("the quick brown fox jumps ", 400))
+	tests := []struct {
+		name     string
+		hyp, ref []string
+		band     int
+	}{
+		{"empty hypothesis", nil, []string{"a", "b", "c"}, wordAlignBand},
+		{"empty hypothesis long reference", nil, long, 10},
+		{"empty reference", []string{"a", "b"}, nil, wordAlignBand},
+		{"both empty", nil, nil, wordAlignBand},
+		{"one word against a long reference", []string{"fox"}, long, 10},
+		{"long hypothesis against one word", long, []string{"fox"}, 10},
+		{"short hypothesis against a long reference", long[:50], long, 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			steps := align(tt.hyp, tt.ref, tt.band)
+			if got, want := editDistance(steps), levenshtein(tt.hyp, tt.ref); got != want {
+				t.Errorf("distance = %d, want %d", got, want)
+			}
+			hyp, ref := 0, 0
+			for _, step := range steps {
+				if step.op != alignDeletion {
+					hyp++
+				}
+				if step.op != alignInsertion {
+					ref++
+				}
+			}
+			if hyp != len(tt.hyp) || ref != len(tt.ref) {
+				t.Errorf("steps cover %d/%d words, want %d/%d", hyp, ref, len(tt.hyp), len(tt.ref))
+			}
+		})
+	}
+}
+
+func TestAlignMatchesLevenshtein(t *testing.T) {
+	rng := rand.New(rand.NewSource(1210))
+	vocab := []string{"a", "b", "c", "d"}
+	words := func(n int) []string {
+		w := make([]string, n)
+		for i := range w {
+			w[i] = vocab[rng.Intn(len(vocab))]
+		}
+		return w
+	}
+	for range 200 {
+		hyp, ref := words(rng.Intn(30)), words(rng.Intn(30))
+		if got, want := editDistance(align(hyp, ref, wordAlignBand)), levenshtein(hyp, ref); got != want {
+			t.Fatalf("align(%v, %v) distance = %d, want %d", hyp, ref, got, want)
+		}
+	}
+}
+
+func TestAccuracyEmptyHypothesis(t *testing.T) {
+	report := (&Caption{}).Accuracy("a reference with several words in it")
+	if report.WER != 1 || report.CER != 1 || report.Deletions != 7 {
+		t.Errorf("report = %+v, want every reference word deleted", report)
+	}
+	c := (&Caption{}).withSubtitles([]SubtitleText{{StartTime: 0, EndTime: 1, Text: "hello"}})
+	long := strings.Repeat("hello world ", 2000)
+	if report := c.Accuracy(long); report.Deletions != 3999 || report.Substitutions != 0 {
+		t.Errorf("long reference report: %d deletions, %d substitutions; want 3999, 0", report.Deletions, report.Substitutions)
+	}
+}
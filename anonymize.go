@@ -0,0 +1,135 @@
+package caption
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	EntityEmail = "EMAIL"
+	EntityPhone = "PHONE"
+	EntityName  = "NAME"
+)
+
+var (
+	emailRegex       = regexp.MustCompile(`[\w.+-]+@[\w-]+(?:\.[\w-]+)+`)
+	spokenEmailRegex = regexp.MustCompile(`(?i)\b[\w.+-]+ at [\w-]+ dot (?:com|org|net|edu|gov|io|co(?: dot uk)?|de)\b`)
+	phoneRegex       = regexp.MustCompile(`(?:\+\d{1,3}[\s.-]?)?(?:\(\d{2,4}\)[\s.-]?|\b\d{2,4}[\s.-])\d{3,4}[\s.-]?\d{3,4}\b`)
+)
+
+type Entity struct {
+	Start int
+	End   int
+	Label string
+}
+
+type EntityRecognizer interface {
+	Recognize(ctx context.Context, text string) ([]Entity, error)
+}
+
+type EntityRecognizerFunc func(ctx context.Context, text string) ([]Entity, error)
+
+func (f EntityRecognizerFunc) Recognize(ctx context.Context, text string) ([]Entity, error) {
+	return f(ctx, text)
+}
+
+type AnonymizeOptions struct {
+	KeepEmails  bool
+	KeepPhones  bool
+	Names       []string
+	Recognizer  EntityRecognizer
+	Replacement func(label string) string
+}
+
+func defaultReplacement(label string) string {
+	return "[" + label + "]"
+}
+
+func namesRegex(names []string) *regexp.Regexp {
+	var quoted []string
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" {
+			quoted = append(quoted, regexp.QuoteMeta(name))
+		}
+	}
+	if len(quoted) == 0 {
+		return nil
+	}
+	sort.Slice(quoted, func(i, j int) bool { return len(quoted[i]) > len(quoted[j]) })
+	return regexp.MustCompile(`(?i)\b(?:` + strings.Join(quoted, "|") + `)\b`)
+}
+
+func (c *Caption) Anonymize(ctx context.Context, opts *AnonymizeOptions) (*Caption, error) {
+	if opts == nil {
+		opts = &AnonymizeOptions{}
+	}
+	replacement := opts.Replacement
+	if replacement == nil {
+		replacement = defaultReplacement
+	}
+	names := namesRegex(opts.Names)
+
+	subs := c.GetSubtitleText()
+	for i := range subs {
+		var entities []Entity
+		match := func(re *regexp.Regexp, label string) {
+			for _, loc := range re.FindAllStringIndex(subs[i].Text, -1) {
+				entities = append(entities, Entity{Start: loc[0], End: loc[1], Label: label})
+			}
+		}
+		if !opts.KeepEmails {
+			match(emailRegex, EntityEmail)
+			match(spokenEmailRegex, EntityEmail)
+		}
+		if !opts.KeepPhones {
+			match(phoneRegex, EntityPhone)
+		}
+		if names != nil {
+			match(names, EntityName)
+		}
+		if opts.Recognizer != nil {
+			found, err := opts.Recognizer.Recognize(ctx, subs[i].Text)
+			if err != nil {
+				return nil, fmt.Errorf("failed to recognize entities: %w", err)
+			}
+			for _, entity := range found {
+				if entity.Start >= 0 && entity.End <= len(subs[i].Text) && entity.Start < entity.End {
+					entities = append(entities, entity)
+				}
+			}
+		}
+		if len(entities) == 0 {
+			continue
+		}
+		subs[i].Text = redact(subs[i].Text, entities, replacement)
+		subs[i].Spans = nil
+	}
+	return c.withSubtitles(subs), nil
+}
+
+func redact(text string, entities []Entity, replacement func(string) string) string {
+	sort.Slice(entities, func(i, j int) bool {
+		if entities[i].Start != entities[j].Start {
+			return entities[i].Start < entities[j].Start
+		}
+		return entities[i].End > entities[j].End
+	})
+	var result strings.Builder
+	pos := 0
+	for _, entity := range entities {
+		if entity.Start < pos {
+			if entity.End > pos {
+				pos = entity.End
+			}
+			continue
+		}
+		result.WriteString(text[pos:entity.Start])
+		result.WriteString(replacement(entity.Label))
+		pos = entity.End
+	}
+	result.WriteString(text[pos:])
+	return result.String()
+}
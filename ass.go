@@ -0,0 +1,138 @@
+package caption
+
+import (
+	"fmt"
+	"image/color"
+	"os"
+	"strings"
+)
+
+const (
+	ASSBorderOutline = 1
+	ASSBorderBox     = 3
+)
+
+type ASSStyle struct {
+	Name         string
+	FontName     string
+	FontSize     int
+	PrimaryColor color.NRGBA
+	OutlineColor color.NRGBA
+	BackColor    color.NRGBA
+	Bold         bool
+	Italic       bool
+	BorderStyle  int
+	Outline      int
+	Shadow       int
+	Alignment    int
+	MarginL      int
+	MarginR      int
+	MarginV      int
+}
+
+type ASSOptions struct {
+	Title    string
+	PlayResX int
+	PlayResY int
+	Style    *ASSStyle
+}
+
+func DefaultASSStyle() *ASSStyle {
+	return &ASSStyle{
+		Name:         "Default",
+		FontName:     "Arial",
+		FontSize:     24,
+		PrimaryColor: color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+		OutlineColor: color.NRGBA{A: 0xff},
+		BackColor:    color.NRGBA{A: 0xbf},
+		BorderStyle:  ASSBorderOutline,
+		Outline:      1,
+		Alignment:    2,
+		MarginL:      32,
+		MarginR:      32,
+		MarginV:      24,
+	}
+}
+
+func assColor(c color.NRGBA) string {
+	return fmt.Sprintf("&H%02X%02X%02X%02X", 0xff-c.A, c.B, c.G, c.R)
+}
+
+func assBool(b bool) int {
+	if b {
+		return -1
+	}
+	return 0
+}
+
+func assText(text string) string {
+	return strings.NewReplacer("{", `\{`, "}", `\}`, "\r\n", `\N`, "\n", `\N`).Replace(text)
+}
+
+func assSpans(spans []StyledSpan) string {
+	var result strings.Builder
+	for _, span := range spans {
+		text := assText(span.Text)
+		if span.Style&StyleItalic != 0 {
+			text = `{\i1}` + text + `{\i0}`
+		}
+		if span.Style&StyleBold != 0 {
+			text = `{\b1}` + text + `{\b0}`
+		}
+		result.WriteString(text)
+	}
+	return result.String()
+}
+
+func (c *Caption) GetASS(opts *ASSOptions) string {
+	if opts == nil {
+		opts = &ASSOptions{}
+	}
+	style := opts.Style
+	if style == nil {
+		style = DefaultASSStyle()
+	}
+	name := style.Name
+	if name == "" {
+		name = "Default"
+	}
+	title := opts.Title
+	if title == "" {
+		title = c.title()
+	}
+	resX, resY := opts.PlayResX, opts.PlayResY
+	if resX <= 0 || resY <= 0 {
+		resX, resY = 640, 360
+	}
+
+	var result strings.Builder
+	result.WriteString("[Script Info]\n")
+	fmt.Fprintf(&result, "Title: %s\n", strings.ReplaceAll(title, "\n", " "))
+	fmt.Fprintf(&result, "ScriptType: v4.00+\nWrapStyle: 0\nScaledBorderAndShadow: yes\nPlayResX: %d\nPlayResY: %d\n\n", resX, resY)
+
+	result.WriteString("[V4+ Styles]\n")
+	result.WriteString("Format: Name, Fontname, Fontsize, PrimaryColour, SecondaryColour, OutlineColour, BackColour, " +
+		"Bold, Italic, Underline, StrikeOut, ScaleX, ScaleY, Spacing, Angle, BorderStyle, Outline, Shadow, " +
+		"Alignment, MarginL, MarginR, MarginV, Encoding\n")
+	fmt.Fprintf(&result, "Style: %s,%s,%d,%s,%s,%s,%s,%d,%d,0,0,100,100,0,0,%d,%d,%d,%d,%d,%d,%d,1\n\n",
+		name, style.FontName, style.FontSize,
+		assColor(style.PrimaryColor), assColor(style.PrimaryColor), assColor(style.OutlineColor), assColor(style.BackColor),
+		assBool(style.Bold), assBool(style.Italic),
+		style.BorderStyle, style.Outline, style.Shadow, style.Alignment, style.MarginL, style.MarginR, style.MarginV)
+
+	result.WriteString("[Events]\n")
+	result.WriteString("Format: Layer, Start, End, Style, Name, MarginL, MarginR, MarginV, Effect, Text\n")
+	for _, sub := range c.GetSubtitleText() {
+		text := assText(sub.Text)
+		if len(sub.Spans) > 0 {
+			text = assSpans(sub.Spans)
+		}
+		fmt.Fprintf(&result, "Dialogue: 0,%s,%s,%s,,0,0,0,,%s\n",
+			formatASSTime(sub.StartDuration()), formatASSTime(sub.EndDuration()), name, text)
+	}
+	return result.String()
+}
+
+func (c *Caption) SaveASS(filename string, opts *ASSOptions) error {
+	return os.WriteFile(filename, []byte(c.GetASS(opts)), 0644)
+}
@@ -0,0 +1,30 @@
+package caption
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGetASS(t *testing.T) {
+	c := (&Caption{VideoID: "abc"}).withSubtitles([]SubtitleText{
+		{StartTime: 1.005, EndTime: 2.5, Text: "plain {braces}\nsecond line"},
+		{StartTime: 3601, EndTime: 3602.25, Text: "say it loud now", Spans: []StyledSpan{
+			{Text: "say "}, {Text: "it", Style: StyleItalic}, {Text: " loud", Style: StyleBold}, {Text: " now", Style: StyleBold | StyleItalic},
+		}},
+	})
+	out := c.GetASS(nil)
+	for _, want := range []string{
+		"[Script Info]\nTitle: abc\nScriptType: v4.00+\n",
+		"PlayResX: 640\nPlayResY: 360\n",
+		"Style: Default,Arial,24,&H00FFFFFF,&H00FFFFFF,&H00000000,&H40000000,0,0,0,0,100,100,0,0,1,1,0,2,32,32,24,1\n",
+		"Dialogue: 0,0:00:01.01,0:00:02.50,Default,,0,0,0,,plain \\{braces\\}\\Nsecond line\n",
+		"Dialogue: 0,1:00:01.00,1:00:02.25,Default,,0,0,0,,say {\\i1}it{\\i0}{\\b1} loud{\\b0}{\\b1}{\\i1} now{\\i0}{\\b0}\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("GetASS output missing %q:\n%s", want, out)
+		}
+	}
+	if got := c.StripFormatting().GetASS(nil); strings.Contains(got, `{\i1}`) || strings.Contains(got, `{\b1}`) {
+		t.Errorf("StripFormatting kept overrides:\n%s", got)
+	}
+}
@@ -0,0 +1,95 @@
+package caption
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+var ErrAudioTrackNotFound = errors.New("audio track not found")
+
+type audioTrackRenderer struct {
+	AudioTrackID             string `json:"audioTrackId"`
+	CaptionTrackIndices      []int  `json:"captionTrackIndices"`
+	DefaultCaptionTrackIndex *int   `json:"defaultCaptionTrackIndex"`
+}
+
+type AudioTrack struct {
+	ID                  string         `json:"id"`
+	DisplayName         string         `json:"displayName"`
+	IsDefault           bool           `json:"isDefault"`
+	CaptionTracks       []CaptionTrack `json:"captionTracks"`
+	DefaultCaptionTrack *CaptionTrack  `json:"defaultCaptionTrack,omitempty"`
+}
+
+func (p *playerResponse) audioTracks() []AudioTrack {
+	type formatInfo struct {
+		displayName string
+		isDefault   bool
+	}
+	formats := make(map[string]formatInfo)
+	for _, f := range p.StreamingData.AdaptiveFormats {
+		if f.AudioTrack != nil {
+			formats[f.AudioTrack.ID] = formatInfo{f.AudioTrack.DisplayName, f.AudioTrack.AudioIsDefault}
+		}
+	}
+
+	renderer := p.Captions.PlayerCaptionsTracklistRenderer
+	var result []AudioTrack
+	for _, at := range renderer.AudioTracks {
+		track := AudioTrack{ID: at.AudioTrackID}
+		if info, ok := formats[at.AudioTrackID]; ok {
+			track.DisplayName = info.displayName
+			track.IsDefault = info.isDefault
+		}
+		for _, i := range at.CaptionTrackIndices {
+			if i >= 0 && i < len(renderer.CaptionTracks) {
+				track.CaptionTracks = append(track.CaptionTracks, renderer.CaptionTracks[i])
+			}
+		}
+		if i := at.DefaultCaptionTrackIndex; i != nil && *i >= 0 && *i < len(renderer.CaptionTracks) {
+			track.DefaultCaptionTrack = &renderer.CaptionTracks[*i]
+		}
+		result = append(result, track)
+	}
+	if len(renderer.AudioTracks) == 1 && result[0].DisplayName == "" {
+		result[0].IsDefault = true
+	}
+	return result
+}
+
+func (p *playerResponse) captionTracksForAudio(selector string) ([]CaptionTrack, error) {
+	for _, at := range p.audioTracks() {
+		if at.ID == selector || strings.EqualFold(at.DisplayName, selector) ||
+			strings.HasPrefix(at.ID, selector+".") {
+			if len(at.CaptionTracks) == 0 {
+				return nil, ErrNoCaptionsFound
+			}
+			return at.CaptionTracks, nil
+		}
+	}
+	return nil, ErrAudioTrackNotFound
+}
+
+func GetAudioTracks(videoID string) ([]AudioTrack, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+	return GetAudioTracksWithContext(ctx, videoID)
+}
+
+func GetAudioTracksWithContext(ctx context.Context, videoID string) ([]AudioTrack, error) {
+	return NewClient(nil).GetAudioTracks(ctx, videoID)
+}
+
+func (c *Client) GetAudioTracks(ctx context.Context, videoID string) ([]AudioTrack, error) {
+	if err := validateVideoID(videoID); err != nil {
+		return nil, err
+	}
+
+	playerResp, err := c.requestPlayer(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+
+	return playerResp.audioTracks(), nil
+}
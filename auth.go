@@ -0,0 +1,86 @@
+package caption
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const youtubeOrigin = "https://www.youtube.com"
+
+func cookieValue(cookies, name string) string {
+	for _, part := range strings.Split(cookies, ";") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if ok && key == name {
+			return value
+		}
+	}
+	return ""
+}
+
+func sapisidHash(sapisid, origin string, now time.Time) string {
+	ts := strconv.FormatInt(now.Unix(), 10)
+	sum := sha1.Sum([]byte(ts + " " + sapisid + " " + origin))
+	return fmt.Sprintf("SAPISIDHASH %s_%s", ts, hex.EncodeToString(sum[:]))
+}
+
+func isYouTubeHost(host string) bool {
+	host = strings.TrimSuffix(strings.TrimPrefix(strings.ToLower(host), "."), ".")
+	return host == "youtube.com" || strings.HasSuffix(host, ".youtube.com")
+}
+
+func (c *Client) authenticate(req *http.Request) {
+	if !isYouTubeHost(req.URL.Hostname()) {
+		return
+	}
+	if c.opts.OAuthToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.opts.OAuthToken)
+		return
+	}
+	if c.opts.Cookies == "" {
+		return
+	}
+	req.Header.Set("Cookie", c.opts.Cookies)
+	sapisid := cookieValue(c.opts.Cookies, "SAPISID")
+	if sapisid == "" {
+		sapisid = cookieValue(c.opts.Cookies, "__Secure-3PAPISID")
+	}
+	if sapisid != "" {
+		req.Header.Set("Authorization", sapisidHash(sapisid, youtubeOrigin, c.opts.clock().Now()))
+		req.Header.Set("Origin", youtubeOrigin)
+		req.Header.Set("X-Origin", youtubeOrigin)
+		req.Header.Set("X-Goog-AuthUser", "0")
+	}
+}
+
+func LoadCookiesFile(filename string) (string, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+
+	var cookies []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimPrefix(scanner.Text(), "#HttpOnly_")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) < 7 || !isYouTubeHost(fields[0]) {
+			continue
+		}
+		cookies = append(cookies, fields[5]+"="+fields[6])
+	}
+	if err = scanner.Err(); err != nil {
+		return "", fmt.Errorf("failed to read cookies file: %w", err)
+	}
+	return strings.Join(cookies, "; "), nil
+}
@@ -0,0 +1,36 @@
+package caption
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAuthenticateOnlyYouTubeHosts(t *testing.T) {
+	c := NewClient(&Options{OAuthToken: "token"})
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://www.youtube.com/youtubei/v1/player", true},
+		{"https://youtube.com/", true},
+		{"https://m.YouTube.com/", true},
+		{"https://evilyoutube.com/", false},
+		{"https://youtube.com.evil.example/", false},
+		{"https://example.com/?youtube.com", false},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", tt.url, nil)
+		c.authenticate(req)
+		if got := req.Header.Get("Authorization") != ""; got != tt.want {
+			t.Errorf("%s: authenticated = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestIsYouTubeHostCookieDomains(t *testing.T) {
+	for host, want := range map[string]bool{".youtube.com": true, "youtube.com": true, ".evilyoutube.com": false, "notyoutube.com": false} {
+		if got := isYouTubeHost(host); got != want {
+			t.Errorf("isYouTubeHost(%q) = %v, want %v", host, got, want)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package caption
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultConcurrency = 4
+
+var ErrSkipped = errors.New("skipped before the batch deadline")
+
+type BatchResult struct {
+	Input   string
+	VideoID string
+	Caption *Caption
+	Err     error
+	Skipped bool
+	Bytes   int64
+	Elapsed time.Duration
+}
+
+type BatchSummary struct {
+	Total      int
+	Succeeded  int
+	Failed     int
+	Skipped    int
+	Duplicates int
+	Bytes      int64
+	Elapsed    time.Duration
+}
+
+func (s BatchSummary) String() string {
+	str := fmt.Sprintf("%d succeeded, %d failed, %d skipped of %d (%d bytes in %s)",
+		s.Succeeded, s.Failed, s.Skipped, s.Total, s.Bytes, s.Elapsed.Round(time.Millisecond))
+	if s.Duplicates > 0 {
+		str += fmt.Sprintf(", %d duplicate transcripts", s.Duplicates)
+	}
+	return str
+}
+
+func SummarizeBatch(results []BatchResult, elapsed time.Duration) BatchSummary {
+	summary := BatchSummary{Total: len(results), Elapsed: elapsed}
+	for _, result := range results {
+		switch {
+		case result.Skipped:
+			summary.Skipped++
+		case result.Err != nil:
+			summary.Failed++
+		default:
+			summary.Succeeded++
+		}
+		summary.Bytes += result.Bytes
+	}
+	for _, group := range FindDuplicates(results) {
+		summary.Duplicates += len(group.VideoIDs) - 1
+	}
+	return summary
+}
+
+func (o *Options) videoTimeout() time.Duration {
+	if o.VideoTimeout > 0 {
+		return o.VideoTimeout
+	}
+	return o.Timeout
+}
+
+func DownloadBatch(ctx context.Context, inputs []string, opts *Options) []BatchResult {
+	return NewClient(opts).DownloadBatch(ctx, inputs)
+}
+
+func DownloadBatchWithSummary(ctx context.Context, inputs []string, opts *Options) ([]BatchResult, BatchSummary) {
+	return NewClient(opts).DownloadBatchWithSummary(ctx, inputs)
+}
+
+func (c *Client) DownloadBatchWithSummary(ctx context.Context, inputs []string) ([]BatchResult, BatchSummary) {
+	clock := c.opts.clock()
+	start := clock.Now()
+	results := c.DownloadBatch(ctx, inputs)
+	return results, SummarizeBatch(results, clock.Now().Sub(start))
+}
+
+func (c *Client) DownloadBatch(ctx context.Context, inputs []string) []BatchResult {
+	opts := c.opts
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	budget := newBatchBudget(opts.Budget, cancel)
+
+	results := make([]BatchResult, len(inputs))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, input := range inputs {
+		results[i].Input = input
+		videoID, err := ExtractVideoID(input)
+		if err != nil {
+			results[i].Err = err
+			c.notify(ctx, results[i])
+			continue
+		}
+		results[i].VideoID = videoID
+
+		wg.Add(1)
+		go func(result *BatchResult) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				result.Skipped, result.Err = true, fmt.Errorf("%w: %w", ErrSkipped, ctx.Err())
+				return
+			case <-c.life.stop:
+				result.Skipped, result.Err = true, fmt.Errorf("%w: %w", ErrSkipped, ErrClientClosed)
+				return
+			}
+			defer func() { <-sem }()
+			if err := ctx.Err(); err != nil {
+				result.Skipped, result.Err = true, fmt.Errorf("%w: %w", ErrSkipped, err)
+				return
+			}
+
+			videoCtx, cancel := context.WithCancel(ctx)
+			if timeout := opts.videoTimeout(); timeout > 0 {
+				videoCtx, cancel = context.WithTimeout(ctx, timeout)
+			}
+			defer cancel()
+			start := opts.clock().Now()
+			result.Caption, result.Err = c.Download(videoCtx, result.VideoID)
+			result.Elapsed = opts.clock().Now().Sub(start)
+			switch {
+			case errors.Is(result.Err, ErrClientClosed):
+				result.Skipped, result.Err = true, fmt.Errorf("%w: %w", ErrSkipped, result.Err)
+			case result.Err != nil && ctx.Err() != nil:
+				result.Skipped, result.Err = true, fmt.Errorf("%w: %w", ErrSkipped, ctx.Err())
+			}
+			if result.Caption != nil {
+				result.Bytes = result.Caption.size
+				if opts.Sink != nil {
+					if err := result.Caption.Publish(ctx, opts.Sink); err != nil {
+						result.Err = fmt.Errorf("failed to publish cues: %w", err)
+					}
+				}
+			}
+			if !result.Skipped {
+				budget.record(result.Err)
+			}
+			c.notify(ctx, *result)
+		}(&results[i])
+	}
+	wg.Wait()
+
+	if err := budget.exceeded(); err != nil {
+		for i := range results {
+			if errors.Is(results[i].Err, context.Canceled) {
+				results[i].Err = err
+			}
+		}
+	}
+	return results
+}
+
+func ReadVideoIDs(r io.Reader) ([]string, error) {
+	var inputs []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t') {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		inputs = append(inputs, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read video IDs: %w", err)
+	}
+	return inputs, nil
+}
+
+func DownloadFromReader(r io.Reader, opts *Options) ([]BatchResult, error) {
+	return DownloadFromReaderWithContext(context.Background(), r, opts)
+}
+
+func DownloadFromReaderWithContext(ctx context.Context, r io.Reader, opts *Options) ([]BatchResult, error) {
+	inputs, err := ReadVideoIDs(r)
+	if err != nil {
+		return nil, err
+	}
+	return DownloadBatch(ctx, inputs, opts), nil
+}
@@ -0,0 +1,54 @@
+package caption
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestDownloadBatchZeroVideoTimeout(t *testing.T) {
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if _, ok := req.Context().Deadline(); ok {
+			t.Error("request has a deadline without Timeout or VideoTimeout")
+		}
+		return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader("")), Request: req}, nil
+	})
+	results := NewClient(&Options{Transport: transport}).DownloadBatch(context.Background(), []string{"dQw4w9WgXcQ"})
+	if results[0].Skipped || results[0].Err == nil || errors.Is(results[0].Err, context.DeadlineExceeded) {
+		t.Errorf("result = %+v, want a non-deadline download error", results[0])
+	}
+}
+
+func TestDownloadBatchDeadlineMidDownloadIsSkipped(t *testing.T) {
+	started := make(chan struct{}, 2)
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		started <- struct{}{}
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	client := NewClient(&Options{Transport: transport, Concurrency: 1, Budget: &BatchBudget{MaxConsecutiveFailures: 1}})
+	results, summary := client.DownloadBatchWithSummary(ctx, []string{"dQw4w9WgXcQ", "jNQXAC9IVRw"})
+	if len(started) != 1 {
+		t.Errorf("%d downloads started, want 1", len(started))
+	}
+	for _, result := range results {
+		if !result.Skipped || !errors.Is(result.Err, ErrSkipped) || !errors.Is(result.Err, context.DeadlineExceeded) {
+			t.Errorf("result for %s = %+v, want skipped at the batch deadline", result.VideoID, result)
+		}
+	}
+	if summary.Skipped != 2 || summary.Failed != 0 {
+		t.Errorf("summary = %v, want 2 skipped", summary)
+	}
+}
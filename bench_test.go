@@ -0,0 +1,136 @@
+package caption
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var benchFixtures = []string{"asr-1h", "manual-2h"}
+
+var benchFormats = []Format{
+	FormatJSON, FormatCues, FormatSRT, FormatVTT, FormatText, FormatMarkdown, FormatHTML, FormatTTML, FormatASS,
+	FormatScript, FormatChunks, FormatXLIFF, FormatTMX, FormatBundle, FormatLRC, FormatEDL, FormatChapters,
+}
+
+func loadFixture(tb testing.TB, name string) []byte {
+	tb.Helper()
+	f, err := os.Open(filepath.Join("testdata", name+".json3.gz"))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return data
+}
+
+func loadFixtureCaption(tb testing.TB, name string) *Caption {
+	tb.Helper()
+	c, err := parseJSON(loadFixture(tb, name))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	c.VideoID = "dQw4w9WgXcQ"
+	return c
+}
+
+func TestFixtures(t *testing.T) {
+	for _, name := range benchFixtures {
+		c := loadFixtureCaption(t, name)
+		subs := c.GetSubtitleText()
+		if len(subs) < 1000 {
+			t.Errorf("%s: %d cues, want a large fixture", name, len(subs))
+		}
+		for _, format := range benchFormats {
+			if err := c.Write(io.Discard, format); err != nil {
+				t.Errorf("%s: failed to write %s: %v", name, format, err)
+			}
+		}
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	for _, name := range benchFixtures {
+		data := loadFixture(b, name)
+		b.Run(name, func(b *testing.B) {
+			b.SetBytes(int64(len(data)))
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := parseJSON(data); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(name+"/stream", func(b *testing.B) {
+			opts := &Options{SkipEmptyEvents: true}
+			b.SetBytes(int64(len(data)))
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := opts.decodeCaption(bytes.NewReader(data)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkParseSubtitles(b *testing.B) {
+	c := loadFixtureCaption(b, "manual-2h")
+	for _, format := range []Format{FormatSRT, FormatVTT} {
+		var buf bytes.Buffer
+		if err := c.Write(&buf, format); err != nil {
+			b.Fatal(err)
+		}
+		data := buf.String()
+		b.Run(string(format), func(b *testing.B) {
+			b.SetBytes(int64(len(data)))
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := Parse(strings.NewReader(data), format); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkGetSubtitleText(b *testing.B) {
+	for _, name := range benchFixtures {
+		c := loadFixtureCaption(b, name)
+		cues := len(c.GetSubtitleText())
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				c.GetSubtitleText()
+			}
+			b.ReportMetric(float64(cues*b.N)/b.Elapsed().Seconds(), "cues/s")
+		})
+	}
+}
+
+func BenchmarkExport(b *testing.B) {
+	c := loadFixtureCaption(b, "asr-1h")
+	cues := len(c.GetSubtitleText())
+	for _, format := range benchFormats {
+		b.Run(string(format), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				if err := c.Write(io.Discard, format); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(cues*b.N)/b.Elapsed().Seconds(), "cues/s")
+		})
+	}
+}
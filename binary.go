@@ -0,0 +1,209 @@
+package caption
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+)
+
+var errInvalidBinary = errors.New("invalid binary caption data")
+
+const (
+	wireVarint = 0
+	wireI64    = 1
+	wireBytes  = 2
+	wireI32    = 5
+)
+
+func appendTag(b []byte, field, wireType int) []byte {
+	return binary.AppendUvarint(b, uint64(field<<3|wireType))
+}
+
+func appendIntField(b []byte, field, v int) []byte {
+	if v == 0 {
+		return b
+	}
+	b = appendTag(b, field, wireVarint)
+	return binary.AppendUvarint(b, uint64(int64(v)))
+}
+
+func appendBytesField(b []byte, field int, v []byte) []byte {
+	b = appendTag(b, field, wireBytes)
+	b = binary.AppendUvarint(b, uint64(len(v)))
+	return append(b, v...)
+}
+
+func appendStringField(b []byte, field int, v string) []byte {
+	if v == "" {
+		return b
+	}
+	return appendBytesField(b, field, []byte(v))
+}
+
+func (c *Caption) MarshalBinary() ([]byte, error) {
+	var b, eb, sb []byte
+	for _, event := range c.Events {
+		eb = appendIntField(eb[:0], 1, event.TStartMs)
+		eb = appendIntField(eb, 3, event.PenID)
+		for _, seg := range event.Segments {
+			sb = appendTag(sb[:0], 1, wireBytes)
+			sb = binary.AppendUvarint(sb, uint64(len(seg.UTF8)))
+			sb = append(sb, seg.UTF8...)
+			sb = appendIntField(sb, 2, seg.TOffsetMs)
+			sb = appendIntField(sb, 3, seg.AcAsrConf)
+			sb = appendIntField(sb, 4, seg.PenID)
+			eb = appendBytesField(eb, 2, sb)
+		}
+		b = appendBytesField(b, 1, eb)
+	}
+	b = appendStringField(b, 2, c.VideoID)
+	for _, pen := range c.Pens {
+		pb := appendIntField(nil, 1, pen.Bold)
+		pb = appendIntField(pb, 2, pen.Italic)
+		pb = appendIntField(pb, 3, pen.Underline)
+		pb = appendIntField(pb, 4, pen.Ruby)
+		b = appendBytesField(b, 3, pb)
+	}
+	return b, nil
+}
+
+type protoField struct {
+	num   int
+	value uint64
+	data  []byte
+}
+
+func readFields(b []byte, fn func(protoField) error) error {
+	for len(b) > 0 {
+		tag, n := binary.Uvarint(b)
+		if n <= 0 {
+			return errInvalidBinary
+		}
+		b = b[n:]
+		f := protoField{num: int(tag >> 3)}
+		switch tag & 7 {
+		case wireVarint:
+			if f.value, n = binary.Uvarint(b); n <= 0 {
+				return errInvalidBinary
+			}
+			b = b[n:]
+		case wireBytes:
+			length, n := binary.Uvarint(b)
+			if n <= 0 || uint64(len(b)-n) < length {
+				return errInvalidBinary
+			}
+			f.data = b[n : n+int(length)]
+			b = b[n+int(length):]
+		case wireI64:
+			if len(b) < 8 {
+				return errInvalidBinary
+			}
+			b = b[8:]
+			continue
+		case wireI32:
+			if len(b) < 4 {
+				return errInvalidBinary
+			}
+			b = b[4:]
+			continue
+		default:
+			return errInvalidBinary
+		}
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Caption) UnmarshalBinary(data []byte) error {
+	var result Caption
+	err := readFields(data, func(f protoField) error {
+		switch f.num {
+		case 1:
+			var event CaptionEvent
+			err := readFields(f.data, func(f protoField) error {
+				switch f.num {
+				case 1:
+					event.TStartMs = int(int64(f.value))
+				case 2:
+					var seg CaptionSegment
+					err := readFields(f.data, func(f protoField) error {
+						switch f.num {
+						case 1:
+							seg.UTF8 = string(f.data)
+						case 2:
+							seg.TOffsetMs = int(int64(f.value))
+						case 3:
+							seg.AcAsrConf = int(int64(f.value))
+						case 4:
+							seg.PenID = int(int64(f.value))
+						}
+						return nil
+					})
+					if err != nil {
+						return err
+					}
+					event.Segments = append(event.Segments, seg)
+				case 3:
+					event.PenID = int(int64(f.value))
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			result.Events = append(result.Events, event)
+		case 2:
+			result.VideoID = string(f.data)
+		case 3:
+			var pen CaptionPen
+			err := readFields(f.data, func(f protoField) error {
+				switch f.num {
+				case 1:
+					pen.Bold = int(int64(f.value))
+				case 2:
+					pen.Italic = int(int64(f.value))
+				case 3:
+					pen.Underline = int(int64(f.value))
+				case 4:
+					pen.Ruby = int(int64(f.value))
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			result.Pens = append(result.Pens, pen)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal caption: %w", err)
+	}
+	c.Pens = result.Pens
+	c.Events = result.Events
+	c.VideoID = result.VideoID
+	return nil
+}
+
+func (c *Caption) SaveBinary(filename string) error {
+	data, err := c.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0644)
+}
+
+func LoadBinary(filename string) (*Caption, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var caption Caption
+	if err = caption.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return &caption, nil
+}
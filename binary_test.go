@@ -0,0 +1,37 @@
+package caption
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	want := &Caption{
+		VideoID: "vStJoetOxJg",
+		Pens:    []CaptionPen{{}, {Bold: 1}, {Italic: 1}, {Ruby: rubyBase}, {Ruby: rubyAfter}},
+		Events: []CaptionEvent{
+			{TStartMs: 0, PenID: 2, Segments: []CaptionSegment{{UTF8: "italic line"}}},
+			{TStartMs: 1500, Segments: []CaptionSegment{
+				{UTF8: "plain "},
+				{UTF8: "bold", TOffsetMs: 400, AcAsrConf: 230, PenID: 1},
+				{UTF8: "漢字", TOffsetMs: 800, PenID: 3},
+				{UTF8: "かんじ", TOffsetMs: 800, PenID: 4},
+			}},
+			{TStartMs: 3000},
+		},
+	}
+	data, err := want.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Caption
+	if err = got.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&got, want) {
+		t.Errorf("round trip mismatch\n got: %+v\nwant: %+v", got, *want)
+	}
+	if got.GetSubtitleText()[1].Spans == nil {
+		t.Error("formatting spans lost after round trip")
+	}
+}
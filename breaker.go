@@ -0,0 +1,156 @@
+package caption
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrCircuitOpen = errors.New("circuit breaker open: too many rate-limit or server errors")
+
+const defaultBreakerCooldown = time.Minute
+
+type BreakerState int
+
+const (
+	BreakerClosed BreakerState = iota
+	BreakerOpen
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+type RequestMetric struct {
+	Method     string
+	Host       string
+	StatusCode int
+	Duration   time.Duration
+	Err        error
+}
+
+type Metrics interface {
+	RequestCompleted(m RequestMetric)
+	BreakerStateChanged(state BreakerState)
+}
+
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	state     BreakerState
+	openedAt  time.Time
+	probing   bool
+	metrics   Metrics
+	clock     Clock
+}
+
+func newCircuitBreaker(opts *Options) *circuitBreaker {
+	if opts.BreakerThreshold <= 0 {
+		return nil
+	}
+	cooldown := opts.BreakerCooldown
+	if cooldown <= 0 {
+		cooldown = defaultBreakerCooldown
+	}
+	return &circuitBreaker{threshold: opts.BreakerThreshold, cooldown: cooldown, metrics: opts.Metrics, clock: opts.clock()}
+}
+
+func (b *circuitBreaker) setState(state BreakerState) bool {
+	if b.state == state {
+		return false
+	}
+	b.state = state
+	return true
+}
+
+func (b *circuitBreaker) notify(changed bool, state BreakerState) {
+	if changed && b.metrics != nil {
+		b.metrics.BreakerStateChanged(state)
+	}
+}
+
+func (b *circuitBreaker) allow() error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	changed, err := b.allowLocked()
+	state := b.state
+	b.mu.Unlock()
+	b.notify(changed, state)
+	return err
+}
+
+func (b *circuitBreaker) allowLocked() (bool, error) {
+	switch b.state {
+	case BreakerOpen:
+		if b.clock.Now().Sub(b.openedAt) < b.cooldown {
+			return false, ErrCircuitOpen
+		}
+		b.probing = true
+		return b.setState(BreakerHalfOpen), nil
+	case BreakerHalfOpen:
+		if b.probing {
+			return false, ErrCircuitOpen
+		}
+		b.probing = true
+	}
+	return false, nil
+}
+
+func (b *circuitBreaker) record(failed bool) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	changed := b.recordLocked(failed)
+	state := b.state
+	b.mu.Unlock()
+	b.notify(changed, state)
+}
+
+func (b *circuitBreaker) recordLocked(failed bool) bool {
+	b.probing = false
+	if !failed {
+		b.failures = 0
+		return b.setState(BreakerClosed)
+	}
+	b.failures++
+	if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+		b.openedAt = b.clock.Now()
+		return b.setState(BreakerOpen)
+	}
+	return false
+}
+
+func (b *circuitBreaker) release() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+func (b *circuitBreaker) State() BreakerState {
+	if b == nil {
+		return BreakerClosed
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+func (c *Client) BreakerState() BreakerState {
+	return c.breaker.State()
+}
@@ -0,0 +1,53 @@
+package caption
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type breakerMetrics struct {
+	breaker *circuitBreaker
+	states  []BreakerState
+	read    []BreakerState
+}
+
+func (m *breakerMetrics) RequestCompleted(RequestMetric) {}
+
+func (m *breakerMetrics) BreakerStateChanged(state BreakerState) {
+	m.states = append(m.states, state)
+	m.read = append(m.read, m.breaker.State())
+}
+
+func TestBreakerMetricsOutsideLock(t *testing.T) {
+	clock := newFakeClock()
+	metrics := &breakerMetrics{}
+	b := newCircuitBreaker(&Options{BreakerThreshold: 1, BreakerCooldown: time.Minute, Clock: clock, Metrics: metrics})
+	metrics.breaker = b
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		b.record(true)
+		if err := b.allow(); err != ErrCircuitOpen {
+			t.Errorf("allow during cooldown = %v, want ErrCircuitOpen", err)
+		}
+		clock.Advance(time.Minute)
+		if err := b.allow(); err != nil {
+			t.Errorf("allow after cooldown = %v", err)
+		}
+		b.record(false)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("BreakerStateChanged deadlocked reading the breaker state")
+	}
+	want := []BreakerState{BreakerOpen, BreakerHalfOpen, BreakerClosed}
+	if !reflect.DeepEqual(metrics.states, want) {
+		t.Errorf("state changes = %v, want %v", metrics.states, want)
+	}
+	if !reflect.DeepEqual(metrics.read, want) {
+		t.Errorf("states read from the callback = %v, want %v", metrics.read, want)
+	}
+}
@@ -0,0 +1,81 @@
+package caption
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var ErrBudgetExceeded = errors.New("batch error budget exceeded")
+
+type BatchBudget struct {
+	MaxConsecutiveFailures int
+	MaxRateLimited         int
+}
+
+type BudgetExceededError struct {
+	ConsecutiveFailures int
+	RateLimited         int
+	LastErr             error
+}
+
+func (e *BudgetExceededError) Error() string {
+	return fmt.Sprintf("%v after %d consecutive failures and %d rate-limited responses: %v",
+		ErrBudgetExceeded, e.ConsecutiveFailures, e.RateLimited, e.LastErr)
+}
+
+func (e *BudgetExceededError) Is(target error) bool {
+	return target == ErrBudgetExceeded
+}
+
+func (e *BudgetExceededError) Unwrap() error {
+	return e.LastErr
+}
+
+type batchBudget struct {
+	limits *BatchBudget
+	cancel context.CancelFunc
+
+	mu          sync.Mutex
+	consecutive int
+	rateLimited int
+	err         error
+}
+
+func newBatchBudget(limits *BatchBudget, cancel context.CancelFunc) *batchBudget {
+	return &batchBudget{limits: limits, cancel: cancel}
+}
+
+func (b *batchBudget) exceeded() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.err
+}
+
+func (b *batchBudget) record(err error) {
+	if b.limits == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.err != nil {
+		return
+	}
+	if err == nil {
+		b.consecutive = 0
+		return
+	}
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	b.consecutive++
+	if errors.Is(err, ErrRateLimited) {
+		b.rateLimited++
+	}
+	if (b.limits.MaxConsecutiveFailures > 0 && b.consecutive >= b.limits.MaxConsecutiveFailures) ||
+		(b.limits.MaxRateLimited > 0 && b.rateLimited >= b.limits.MaxRateLimited) {
+		b.err = &BudgetExceededError{ConsecutiveFailures: b.consecutive, RateLimited: b.rateLimited, LastErr: err}
+		b.cancel()
+	}
+}
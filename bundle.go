@@ -0,0 +1,105 @@
+package caption
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+type BundleMetadata struct {
+	VideoID string        `json:"videoId"`
+	Video   *VideoInfo    `json:"video,omitempty"`
+	Track   *CaptionTrack `json:"track,omitempty"`
+}
+
+func (c *Caption) bundleMetadata() BundleMetadata {
+	return BundleMetadata{
+		VideoID: c.VideoID,
+		Video:   c.Video,
+		Track:   c.Track,
+	}
+}
+
+func (c *Caption) writeBundle(w io.Writer) error {
+	captionJSON, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal caption: %w", err)
+	}
+	metadataJSON, err := json.MarshalIndent(c.bundleMetadata(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	files := []struct {
+		name string
+		data []byte
+	}{
+		{"captions.json", captionJSON},
+		{"captions.srt", []byte(c.GetSRT())},
+		{"captions.vtt", []byte(c.GetVTT())},
+		{"captions.txt", []byte(c.GetPlainText())},
+		{"metadata.json", metadataJSON},
+	}
+
+	zw := zip.NewWriter(w)
+	for _, file := range files {
+		fw, err := zw.Create(file.name)
+		if err != nil {
+			return fmt.Errorf("failed to create %s in bundle: %w", file.name, err)
+		}
+		if _, err = fw.Write(file.data); err != nil {
+			return fmt.Errorf("failed to write %s to bundle: %w", file.name, err)
+		}
+	}
+	return zw.Close()
+}
+
+func LoadBundle(filename string) (*Caption, error) {
+	zr, err := zip.OpenReader(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bundle: %w", err)
+	}
+	defer func() { _ = zr.Close() }()
+
+	readFile := func(name string) ([]byte, error) {
+		f, err := zr.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = f.Close() }()
+		return io.ReadAll(f)
+	}
+
+	data, err := readFile("captions.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read captions.json from bundle: %w", err)
+	}
+	c, err := parseJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	if data, err = readFile("metadata.json"); err == nil {
+		var metadata BundleMetadata
+		if err = json.Unmarshal(data, &metadata); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal bundle metadata: %w", err)
+		}
+		c.VideoID = metadata.VideoID
+		c.Video = metadata.Video
+		c.Track = metadata.Track
+	}
+	return c, nil
+}
+
+func (c *Caption) SaveBundle(filename string) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create bundle: %w", err)
+	}
+	if err = c.writeBundle(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
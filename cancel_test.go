@@ -0,0 +1,107 @@
+package caption
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const cancelBound = time.Second
+
+func serverTransport(srv *httptest.Server) http.RoundTripper {
+	target, _ := url.Parse(srv.URL)
+	return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		req = req.Clone(req.Context())
+		req.URL.Scheme, req.URL.Host = target.Scheme, target.Host
+		return srv.Client().Transport.RoundTrip(req)
+	})
+}
+
+func assertCanceled(t *testing.T, err error, canceledAt time.Time) {
+	t.Helper()
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+	if elapsed := time.Since(canceledAt); elapsed > cancelBound {
+		t.Errorf("returned %v after cancel, want within %v", elapsed, cancelBound)
+	}
+}
+
+func TestDownloadCanceledMidRetry(t *testing.T) {
+	var hits atomic.Int32
+	first := make(chan struct{}, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		select {
+		case first <- struct{}{}:
+		default:
+		}
+		http.Error(w, "unavailable", http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	opts := DefaultOptions()
+	opts.Transport, opts.MaxRetries = serverTransport(srv), 10
+	ctx, cancel := context.WithCancel(context.Background())
+	var canceledAt time.Time
+	go func() {
+		<-first
+		time.Sleep(50 * time.Millisecond)
+		canceledAt = time.Now()
+		cancel()
+	}()
+	_, err := NewClient(opts).Download(ctx, "dQw4w9WgXcQ")
+	assertCanceled(t, err, canceledAt)
+	if n := hits.Load(); n > 2 {
+		t.Errorf("%d requests, want the retry loop to stop at cancellation", n)
+	}
+}
+
+func TestDownloadCanceledMidRead(t *testing.T) {
+	for _, lowMemory := range []bool{false, true} {
+		t.Run(fmt.Sprintf("LowMemory=%v", lowMemory), func(t *testing.T) {
+			streaming := make(chan struct{})
+			released := make(chan struct{})
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/youtubei/v1/player":
+					_, _ = w.Write([]byte(`{"playabilityStatus":{"status":"OK"},"captions":{"playerCaptionsTracklistRenderer":{"captionTracks":[` +
+						`{"baseUrl":"https://www.youtube.com/api/timedtext?v=dQw4w9WgXcQ&lang=en&kind=asr","languageCode":"en","kind":"asr"}]}}}`))
+				case "/api/timedtext":
+					_, _ = w.Write([]byte(`{"events":[{"tStartMs":0,"segs":[{"utf8":"partial"}]},`))
+					w.(http.Flusher).Flush()
+					close(streaming)
+					<-r.Context().Done()
+					close(released)
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer srv.Close()
+
+			opts := DefaultOptions()
+			opts.Transport, opts.LowMemory = serverTransport(srv), lowMemory
+			ctx, cancel := context.WithCancel(context.Background())
+			var canceledAt time.Time
+			go func() {
+				<-streaming
+				time.Sleep(50 * time.Millisecond)
+				canceledAt = time.Now()
+				cancel()
+			}()
+			_, err := NewClient(opts).Download(ctx, "dQw4w9WgXcQ")
+			assertCanceled(t, err, canceledAt)
+			select {
+			case <-released:
+			case <-time.After(cancelBound):
+				t.Error("server handler still blocked after cancel: the response body was not closed")
+			}
+		})
+	}
+}
@@ -7,8 +7,12 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/url"
 	"regexp"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -18,19 +22,25 @@ var (
 	ErrInvalidVideoID  = errors.New("invalid video ID")
 	ErrNoCaptionsFound = errors.New("no captions found for this video")
 	ErrRateLimited     = errors.New("rate limited by YouTube")
+	ErrLiveInProgress  = errors.New("live stream in progress, captions are available after it ends")
 )
 
 type CaptionTrack struct {
-	BaseURL      string `json:"baseUrl"`
-	LanguageCode string `json:"languageCode"`
+	BaseURL      TrackURL `json:"baseUrl"`
+	LanguageCode string   `json:"languageCode"`
 	Name         struct {
 		SimpleText string `json:"simpleText"`
 	} `json:"name"`
-	Kind string `json:"kind"`
+	Kind  string `json:"kind"`
+	VssID string `json:"vssId,omitempty"`
+
+	Usable         bool                `json:"usable"`
+	UnusableReason TrackUnusableReason `json:"unusableReason,omitempty"`
 }
 
 type CaptionEvent struct {
 	TStartMs int              `json:"tStartMs"`
+	PenID    int              `json:"pPenId,omitempty"`
 	Segments []CaptionSegment `json:"segs,omitempty"`
 }
 
@@ -38,24 +48,100 @@ type CaptionSegment struct {
 	UTF8      string `json:"utf8"`
 	TOffsetMs int    `json:"tOffsetMs"`
 	AcAsrConf int    `json:"acAsrConf"`
+	PenID     int    `json:"pPenId,omitempty"`
 }
 
 type Caption struct {
+	Pens   []CaptionPen   `json:"pens,omitempty"`
 	Events []CaptionEvent `json:"events"`
+
+	VideoID string        `json:"-"`
+	Video   *VideoInfo    `json:"-"`
+	Track   *CaptionTrack `json:"-"`
+
+	Corrections []Correction `json:"-"`
+	Warnings    []Warning    `json:"-"`
+
+	size       int64
+	downloaded time.Time
+	client     *Client
+	xliff      *xliffFile
+}
+
+type VideoInfo struct {
+	VideoID       string `json:"videoId"`
+	Title         string `json:"title"`
+	Author        string `json:"author"`
+	ChannelID     string `json:"channelId"`
+	LengthSeconds int    `json:"lengthSeconds,string"`
+	Description   string `json:"shortDescription,omitempty"`
+	IsLive        bool   `json:"isLive,omitempty"`
+	IsLiveContent bool   `json:"isLiveContent,omitempty"`
+	IsUpcoming    bool   `json:"isUpcoming,omitempty"`
+
+	Thumbnail ThumbnailList `json:"thumbnail"`
+
+	Microformat *Microformat `json:"microformat,omitempty"`
+	Heatmap     []HeatMarker `json:"heatmap,omitempty"`
 }
 
 type SubtitleText struct {
-	StartTime float64
-	EndTime   float64
-	Text      string
+	StartTime float64      `json:"start"`
+	EndTime   float64      `json:"end"`
+	Text      string       `json:"text"`
+	Spans     []StyledSpan `json:"spans,omitempty"`
 }
 
 type Options struct {
-	Language   string
-	Kind       string
-	Timeout    time.Duration
-	MaxRetries int
-	UserAgent  string
+	Language            string
+	Languages           []string
+	Kind                string
+	Timeout             time.Duration
+	MaxRetries          int
+	UserAgent           string
+	Concurrency         int
+	Proxy               string
+	CacheDir            string
+	CacheTTL            time.Duration
+	RateLimit           float64
+	AudioTrack          string
+	Forced              ForcedMode
+	StrictLanguage      bool
+	BreakerThreshold    int
+	BreakerCooldown     time.Duration
+	Metrics             Metrics
+	Adaptive            *AdaptiveThrottle
+	Resolver            *net.Resolver
+	DialContext         func(ctx context.Context, network, addr string) (net.Conn, error)
+	DisableIPv6         bool
+	Headers             map[string]string
+	RequestMutator      func(*http.Request)
+	OAuthToken          string
+	Cookies             string
+	SliceFromTimestamp  bool
+	PlayerCacheTTL      time.Duration
+	SkipEmptyEvents     bool
+	SkipNonSpeechEvents bool
+	LowMemory           bool
+	KeepSegments        bool
+	Budget              *BatchBudget
+	VideoTimeout        time.Duration
+	Region              string
+	Heatmap             bool
+	Notifier            Notifier
+	NotifyErrors        func(BatchResult, error)
+	Sink                CueSink
+	Sanitize            bool
+	Clients             []InnerTubeClient
+	DiscoverVersions    bool
+	MaxResponseBytes    int64
+	MaxEvents           int
+	KeepPartial         bool
+	OnWarning           func(videoID string, w Warning)
+	Clock               Clock
+	Rand                Rand
+	HTTPClient          *http.Client
+	Transport           http.RoundTripper
 }
 
 const (
@@ -77,103 +163,266 @@ func validateVideoID(videoID string) error {
 	return nil
 }
 
-func makeRequestWithRetry(ctx context.Context, client *http.Client, req *http.Request, maxRetries int) (*http.Response, error) {
+func (c *Client) do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	ctx, end, err := c.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, value := range c.opts.Headers {
+		req.Header.Set(key, value)
+	}
+	c.authenticate(req)
+	if c.opts.RequestMutator != nil {
+		c.opts.RequestMutator(req)
+	}
+
+	limiter := c.limiter
+	clock := c.opts.clock()
 	var resp *http.Response
+	attempts := 0
 	operation := func() error {
+		if err := ctx.Err(); err != nil {
+			return backoff.Permanent(err)
+		}
+		if err := limiter.wait(ctx, clock); err != nil {
+			return backoff.Permanent(err)
+		}
+		if err := c.breaker.allow(); err != nil {
+			return backoff.Permanent(err)
+		}
+		if err := c.throttle.wait(ctx); err != nil {
+			c.breaker.release()
+			return backoff.Permanent(err)
+		}
 		reqWithCtx := req.WithContext(ctx)
+		if attempts++; attempts > 1 {
+			c.usage.retries.Add(1)
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					c.breaker.release()
+					return backoff.Permanent(fmt.Errorf("failed to rewind request body: %w", err))
+				}
+				reqWithCtx.Body = body
+			}
+		}
+		c.usage.requests.Add(1)
+		start := clock.Now()
 		var err error
-		resp, err = client.Do(reqWithCtx)
+		resp, err = c.httpClient.Do(reqWithCtx)
+		elapsed := clock.Now().Sub(start)
+		c.observe(req, resp, elapsed, err)
+		c.throttle.observe(resp, elapsed)
 		if err != nil {
+			c.usage.errors.Add(1)
+			c.breaker.release()
 			return err
 		}
-		switch resp.StatusCode {
-		case http.StatusOK:
+		resp.Body = &usageBody{ReadCloser: resp.Body, bytes: &c.usage.bytes}
+		c.breaker.record(resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500)
+		if resp.StatusCode == http.StatusOK {
 			return nil
-		case http.StatusTooManyRequests:
-			_ = resp.Body.Close()
-			return ErrRateLimited
-		case http.StatusNotFound:
-			_ = resp.Body.Close()
-			return ErrNoCaptionsFound
+		}
+		c.usage.errors.Add(1)
+		if resp.StatusCode == http.StatusTooManyRequests {
+			c.usage.rateLimited.Add(1)
+		}
+		httpErr := &HTTPError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Snippet:    readSnippet(resp.Body),
+		}
+		_ = resp.Body.Close()
+		switch {
+		case resp.StatusCode == http.StatusTooManyRequests:
+			httpErr.Err = ErrRateLimited
+			return httpErr
+		case resp.StatusCode == http.StatusNotFound:
+			httpErr.Err = ErrNoCaptionsFound
+			return backoff.Permanent(httpErr)
+		case resp.StatusCode >= 500:
+			return httpErr
 		default:
-			if resp.StatusCode >= 500 {
-				_ = resp.Body.Close()
-				return fmt.Errorf("server error: HTTP %d", resp.StatusCode)
-			}
-			return fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
+			return backoff.Permanent(httpErr)
 		}
 	}
 
-	backoffConfig := backoff.NewExponentialBackOff()
-	backoffConfig.MaxElapsedTime = time.Duration(maxRetries) * 10 * time.Second
-	err := backoff.Retry(operation, backoffConfig)
-	return resp, err
+	err = backoff.RetryNotifyWithTimer(operation, backoff.WithContext(c.opts.backOff(), ctx), nil, &clockTimer{clock: clock})
+	if err != nil {
+		end()
+		return resp, err
+	}
+	body := resp.Body
+	resp.Body = &endBody{ReadCloser: struct {
+		io.Reader
+		io.Closer
+	}{&contextReader{ctx: ctx, r: body}, body}, end: sync.OnceFunc(end)}
+	return resp, nil
 }
 
-func makeRequestData(videoID string) ([]byte, error) {
+func makeRequestData(videoID string, client InnerTubeClient, opts *Options) ([]byte, error) {
 	var playerReq struct {
 		Context struct {
 			Client struct {
 				ClientName    string `json:"clientName"`
 				ClientVersion string `json:"clientVersion"`
+				GL            string `json:"gl,omitempty"`
 			} `json:"client"`
 		} `json:"context"`
 		VideoID string `json:"videoId"`
 	}
 	playerReq.VideoID = videoID
-	playerReq.Context.Client.ClientName = "WEB"
-	playerReq.Context.Client.ClientVersion = "2.20250925.01.00"
+	playerReq.Context.Client.ClientName = client.Name
+	playerReq.Context.Client.ClientVersion = client.Version
+	playerReq.Context.Client.GL = strings.ToUpper(opts.Region)
 	return json.Marshal(playerReq)
 }
 
-func extractCaptionTracks(resp *http.Response) ([]CaptionTrack, error) {
-	body, err := io.ReadAll(resp.Body)
+type playerResponse struct {
+	VideoDetails      VideoInfo         `json:"videoDetails"`
+	PlayabilityStatus playabilityStatus `json:"playabilityStatus"`
+	Microformat       struct {
+		PlayerMicroformatRenderer Microformat `json:"playerMicroformatRenderer"`
+	} `json:"microformat"`
+	Captions struct {
+		PlayerCaptionsTracklistRenderer struct {
+			CaptionTracks []CaptionTrack       `json:"captionTracks"`
+			AudioTracks   []audioTrackRenderer `json:"audioTracks"`
+		} `json:"playerCaptionsTracklistRenderer"`
+	} `json:"captions"`
+	StreamingData struct {
+		AdaptiveFormats []struct {
+			AudioTrack *struct {
+				ID             string `json:"id"`
+				DisplayName    string `json:"displayName"`
+				AudioIsDefault bool   `json:"audioIsDefault"`
+			} `json:"audioTrack"`
+		} `json:"adaptiveFormats"`
+	} `json:"streamingData"`
+
+	client string
+}
+
+func readPlayerResponse(resp *http.Response, opts *Options) (*playerResponse, error) {
+	body, err := opts.readLimited(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-	var playerResp struct {
-		Captions struct {
-			PlayerCaptionsTracklistRenderer struct {
-				CaptionTracks []CaptionTrack `json:"captionTracks"`
-			} `json:"playerCaptionsTracklistRenderer"`
-		} `json:"captions"`
+		return nil, newStageError(ErrPlayerRequest, fmt.Errorf("failed to read response: %w", err), nil)
 	}
+	var playerResp playerResponse
 	if err = json.Unmarshal(body, &playerResp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		return nil, newStageError(ErrParse, fmt.Errorf("failed to unmarshal response: %w", err), body)
 	}
+	return &playerResp, nil
+}
+
+func extractCaptionTracks(playerResp *playerResponse, region string) ([]CaptionTrack, error) {
 	tracks := playerResp.Captions.PlayerCaptionsTracklistRenderer.CaptionTracks
 	if len(tracks) == 0 {
+		if blocked := playerResp.regionBlocked(region); blocked != nil {
+			return nil, blocked
+		}
+		if playerResp.upcoming() {
+			return nil, &NotYetAvailableError{
+				VideoID:        playerResp.VideoDetails.VideoID,
+				ScheduledStart: playerResp.scheduledStart(),
+			}
+		}
+		if playerResp.VideoDetails.IsLive {
+			return nil, ErrLiveInProgress
+		}
 		return nil, ErrNoCaptionsFound
 	}
 	return tracks, nil
 }
 
+func (o *Options) languageChain() []string {
+	chain := make([]string, 0, len(o.Languages)+1)
+	if o.Language != "" {
+		chain = append(chain, o.Language)
+	}
+	for _, lang := range o.Languages {
+		if lang != "" && lang != o.Language {
+			chain = append(chain, lang)
+		}
+	}
+	return chain
+}
+
 func findCaptionTrack(tracks []CaptionTrack, opts *Options) (*CaptionTrack, error) {
-	for _, track := range tracks {
-		if track.LanguageCode == opts.Language && track.Kind == opts.Kind {
-			if track.BaseURL != "" {
-				return &track, nil
+	for _, lang := range opts.languageChain() {
+		for _, track := range tracks {
+			if track.LanguageCode == lang && track.Kind == opts.Kind {
+				if track.BaseURL.URL != "" {
+					return &track, nil
+				}
 			}
 		}
-	}
 
-	for _, track := range tracks {
-		if track.LanguageCode == opts.Language {
-			if track.BaseURL != "" {
-				return &track, nil
+		for _, track := range tracks {
+			if track.LanguageCode == lang {
+				if track.BaseURL.URL != "" {
+					return &track, nil
+				}
 			}
 		}
 	}
 
-	if len(tracks) > 0 && tracks[0].BaseURL != "" {
+	if !opts.StrictLanguage && len(tracks) > 0 && tracks[0].BaseURL.URL != "" {
 		return &tracks[0], nil
 	}
 
-	return nil, ErrNoCaptionsFound
+	return nil, &NoCaptionsError{Language: opts.Language, Tracks: tracks}
 }
 
-func requestCaptionTrack(ctx context.Context, client *http.Client, videoID string, opts *Options) (*CaptionTrack, error) {
-	data, err := makeRequestData(videoID)
+func (c *Client) requestPlayer(ctx context.Context, videoID string) (*playerResponse, error) {
+	key := c.playerCacheKey(videoID)
+	if c.opts.PlayerCacheTTL > 0 {
+		if playerResp, ok := c.players.get(key); ok {
+			return playerResp, nil
+		}
+	}
+
+	playerResp, err := c.fetchPlayer(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+	if c.opts.PlayerCacheTTL > 0 {
+		c.players.put(key, playerResp, c.opts.PlayerCacheTTL)
+	}
+	return playerResp, nil
+}
+
+func (c *Client) fetchPlayer(ctx context.Context, videoID string) (*playerResponse, error) {
+	var playerResp *playerResponse
+	var lastErr error
+	for _, client := range c.opts.innerTubeClients() {
+		resp, err := c.requestPlayerAs(ctx, videoID, client)
+		if err != nil {
+			if !retryWithNextClient(err) {
+				return nil, err
+			}
+			lastErr = err
+			continue
+		}
+		if playerResp == nil {
+			playerResp = resp
+		}
+		if !resp.needsFallback() {
+			playerResp = resp
+			break
+		}
+	}
+	if playerResp == nil {
+		return nil, lastErr
+	}
+	playerResp.bindTrackURLs(c)
+	playerResp.annotateTracks()
+	return playerResp, nil
+}
+
+func (c *Client) requestPlayerWith(ctx context.Context, videoID string, client InnerTubeClient) (*playerResponse, error) {
+	data, err := makeRequestData(videoID, client, c.opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request data: %w", err)
 	}
@@ -183,71 +432,156 @@ func requestCaptionTrack(ctx context.Context, client *http.Client, videoID strin
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", opts.UserAgent)
+	req.Header.Set("User-Agent", c.opts.userAgentFor(client))
 
-	resp, err := makeRequestWithRetry(ctx, client, req, opts.MaxRetries)
+	resp, err := c.do(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get response: %w", err)
+		return nil, newStageError(ErrPlayerRequest, err, nil)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	tracks, err := extractCaptionTracks(resp)
+	playerResp, err := readPlayerResponse(resp, c.opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to extract caption tracks: %w", err)
+		return nil, err
+	}
+	playerResp.client = client.Name
+	return playerResp, nil
+}
+
+func (c *Client) requestCaptionTrack(ctx context.Context, videoID string) (*CaptionTrack, *VideoInfo, []Warning, error) {
+	opts := c.opts
+	playerResp, err := c.requestPlayer(ctx, videoID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	tracks, err := extractCaptionTracks(playerResp, opts.Region)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to extract caption tracks: %w", err)
+	}
+
+	available := tracks
+	if opts.AudioTrack != "" {
+		if tracks, err = playerResp.captionTracksForAudio(opts.AudioTrack); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	if tracks = applyForcedMode(tracks, opts.Forced); len(tracks) == 0 {
+		return nil, nil, nil, &NoCaptionsError{VideoID: videoID, Language: opts.Language, Tracks: available}
 	}
 
 	track, err := findCaptionTrack(tracks, opts)
 	if err != nil {
-		return nil, err
+		var noCaptions *NoCaptionsError
+		if errors.As(err, &noCaptions) {
+			noCaptions.VideoID = videoID
+			noCaptions.Tracks = available
+		}
+		return nil, nil, nil, err
 	}
 
-	return track, nil
+	warnings := append(playerResp.clientWarnings(opts.innerTubeClients()), trackWarnings(track, opts)...)
+	return track, playerResp.videoInfo(), warnings, nil
 }
 
-func requestTimedText(ctx context.Context, client *http.Client, track *CaptionTrack, opts *Options) (*Caption, error) {
-	captionURL := track.BaseURL + "&fmt=json3"
+func (c *Client) requestTimedTextResponse(ctx context.Context, track *CaptionTrack) (*http.Response, error) {
+	if track.BaseURL.IsExpired() {
+		if err := track.BaseURL.Refresh(ctx); err != nil {
+			return nil, newStageError(ErrTrackFetch, err, nil)
+		}
+	}
+	captionURL := track.BaseURL.URL + "&fmt=json3"
 	req, err := http.NewRequest("GET", captionURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
-	req.Header.Set("User-Agent", opts.UserAgent)
+	req.Header.Set("User-Agent", c.opts.UserAgent)
 
-	resp, err := makeRequestWithRetry(ctx, client, req, opts.MaxRetries)
+	resp, err := c.do(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get response: %w", err)
+		return nil, newStageError(ErrTrackFetch, err, nil)
+	}
+	return resp, nil
+}
+
+func (c *Client) requestTimedText(ctx context.Context, track *CaptionTrack) (*Caption, error) {
+	caption, err := c.decodeTimedText(ctx, track)
+	if err != nil {
+		return nil, err
+	}
+	if c.opts.Sanitize {
+		caption.Corrections = sanitizeEvents(caption.Events)
+		caption.Warnings = append(caption.Warnings, correctionWarning(caption.Corrections)...)
+	}
+	return caption, nil
+}
+
+func (c *Client) decodeTimedText(ctx context.Context, track *CaptionTrack) (*Caption, error) {
+	resp, err := c.requestTimedTextResponse(ctx, track)
+	if err != nil {
+		return nil, err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
+	if c.opts.streamsDecode() {
+		caption, err := c.opts.decodeCaption(resp.Body)
+		if err != nil && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return caption, err
+	}
+
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read subtitle response: %w", err)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, newStageError(ErrTrackFetch, fmt.Errorf("failed to read subtitle response: %w", err), nil)
 	}
 
 	var caption Caption
 	if err = json.Unmarshal(body, &caption); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal subtitle response: %w", err)
+		return nil, newStageError(ErrParse, fmt.Errorf("failed to unmarshal subtitle response: %w", err), body)
 	}
+	caption.size = int64(len(body))
 	return &caption, nil
 }
 
-func newHTTPClient(timeout time.Duration) *http.Client {
+func newHTTPClient(opts *Options) *http.Client {
+	if opts.HTTPClient != nil {
+		return opts.HTTPClient
+	}
+	if opts.Transport != nil {
+		return &http.Client{Timeout: opts.Timeout, Transport: opts.Transport}
+	}
+	transport := &http.Transport{
+		Proxy:              http.ProxyFromEnvironment,
+		DialContext:        dialContext(opts),
+		MaxIdleConns:       10,
+		IdleConnTimeout:    30 * time.Second,
+		DisableCompression: false,
+	}
+	if opts.Proxy != "" {
+		if proxyURL, err := url.Parse(opts.Proxy); err == nil {
+			transport.Proxy = http.ProxyURL(proxyURL)
+		}
+	}
 	return &http.Client{
-		Timeout: timeout,
-		Transport: &http.Transport{
-			MaxIdleConns:       10,
-			IdleConnTimeout:    30 * time.Second,
-			DisableCompression: false,
-		},
+		Timeout:   opts.Timeout,
+		Transport: transport,
 	}
 }
 
 func DefaultOptions() *Options {
 	return &Options{
-		Language:   "en",
-		Kind:       "asr",
-		Timeout:    defaultTimeout,
-		MaxRetries: defaultMaxRetries,
-		UserAgent:  defaultUA,
+		Language:       "en",
+		Kind:           "asr",
+		Timeout:        defaultTimeout,
+		MaxRetries:     defaultMaxRetries,
+		UserAgent:      defaultUA,
+		Concurrency:    defaultConcurrency,
+		PlayerCacheTTL: defaultPlayerCacheTTL,
 	}
 }
 
@@ -262,25 +596,50 @@ func DownloadWithOptions(videoID string, opts *Options) (*Caption, error) {
 }
 
 func DownloadWithContext(ctx context.Context, videoID string, opts *Options) (*Caption, error) {
+	return NewClient(opts).Download(ctx, videoID)
+}
+
+func (c *Client) Download(ctx context.Context, videoID string) (*Caption, error) {
 	if err := validateVideoID(videoID); err != nil {
 		return nil, err
 	}
-
-	if opts == nil {
-		opts = DefaultOptions()
+	ctx, end, err := c.begin(ctx)
+	if err != nil {
+		return nil, err
 	}
+	defer end()
 
-	client := newHTTPClient(opts.Timeout)
+	if caption, ok := readCache(c.opts, videoID); ok {
+		caption.client = c
+		c.warn(videoID, caption.Warnings)
+		return caption, nil
+	}
+	return c.fetch(ctx, videoID)
+}
 
-	track, err := requestCaptionTrack(ctx, client, videoID, opts)
+func (c *Client) fetch(ctx context.Context, videoID string) (*Caption, error) {
+	track, video, warnings, err := c.requestCaptionTrack(ctx, videoID)
 	if err != nil {
 		return nil, err
 	}
 
-	caption, err := requestTimedText(ctx, client, track, opts)
+	caption, err := c.requestTimedText(ctx, track)
 	if err != nil {
 		return nil, err
 	}
+	if c.opts.Heatmap {
+		if video.Heatmap, err = c.GetHeatmap(ctx, videoID); err != nil {
+			return nil, err
+		}
+	}
+	caption.VideoID = videoID
+	caption.Video = video
+	caption.Track = track
+	caption.downloaded = c.opts.clock().Now()
+	caption.client = c
+	caption.Warnings = append(warnings, caption.Warnings...)
+	c.warn(videoID, caption.Warnings)
+	writeCache(c.opts, videoID, caption)
 
 	return caption, nil
 }
@@ -292,30 +651,22 @@ func GetAvailableTracks(videoID string) ([]CaptionTrack, error) {
 }
 
 func GetAvailableTracksWithContext(ctx context.Context, videoID string) ([]CaptionTrack, error) {
-	if err := validateVideoID(videoID); err != nil {
-		return nil, err
-	}
-
-	opts := DefaultOptions()
-	client := newHTTPClient(opts.Timeout)
+	return NewClient(nil).GetAvailableTracks(ctx, videoID)
+}
 
-	data, err := makeRequestData(videoID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request data: %w", err)
-	}
+func GetAvailableTracksWithOptions(ctx context.Context, videoID string, opts *Options) ([]CaptionTrack, error) {
+	return NewClient(opts).GetAvailableTracks(ctx, videoID)
+}
 
-	req, err := http.NewRequest("POST", playerURL, bytes.NewBuffer(data))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+func (c *Client) GetAvailableTracks(ctx context.Context, videoID string) ([]CaptionTrack, error) {
+	if err := validateVideoID(videoID); err != nil {
+		return nil, err
 	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", opts.UserAgent)
 
-	resp, err := makeRequestWithRetry(ctx, client, req, opts.MaxRetries)
+	playerResp, err := c.requestPlayer(ctx, videoID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get response: %w", err)
+		return nil, err
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	return extractCaptionTracks(resp)
+	return extractCaptionTracks(playerResp, c.opts.Region)
 }
@@ -0,0 +1,114 @@
+package caption
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var ErrChannelNotFound = errors.New("channel not found")
+
+const (
+	channelURL     = "https://www.youtube.com/"
+	channelFeedURL = "https://www.youtube.com/feeds/videos.xml?channel_id="
+)
+
+var (
+	channelIDRegex     = regexp.MustCompile(`^UC[a-zA-Z0-9_-]{22}$`)
+	channelIDPageRegex = regexp.MustCompile(`"(?:externalId|channelId)":"(UC[a-zA-Z0-9_-]{22})"`)
+)
+
+type FeedEntry struct {
+	VideoID   string    `xml:"http://www.youtube.com/xml/schemas/2015 videoId"`
+	ChannelID string    `xml:"http://www.youtube.com/xml/schemas/2015 channelId"`
+	Title     string    `xml:"title"`
+	Published time.Time `xml:"published"`
+}
+
+func ResolveChannelID(ctx context.Context, channel string, opts *Options) (string, error) {
+	return NewClient(opts).ResolveChannelID(ctx, channel)
+}
+
+func (c *Client) ResolveChannelID(ctx context.Context, channel string) (string, error) {
+	channel = strings.TrimSpace(channel)
+	if channelIDRegex.MatchString(channel) {
+		return channel, nil
+	}
+
+	path := channel
+	if u, err := url.Parse(channel); err == nil && u.Host != "" {
+		path = strings.Trim(u.Path, "/")
+	}
+	if id := strings.TrimPrefix(path, "channel/"); channelIDRegex.MatchString(id) {
+		return id, nil
+	}
+	if !strings.HasPrefix(path, "@") && !strings.HasPrefix(path, "c/") && !strings.HasPrefix(path, "user/") {
+		path = "@" + path
+	}
+
+	req, err := http.NewRequest("GET", channelURL+path, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", c.opts.UserAgent)
+
+	resp, err := c.do(ctx, req)
+	if err != nil {
+		if errors.Is(err, ErrNoCaptionsFound) {
+			return "", ErrChannelNotFound
+		}
+		return "", fmt.Errorf("failed to get response: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read channel page: %w", err)
+	}
+	m := channelIDPageRegex.FindSubmatch(body)
+	if m == nil {
+		return "", ErrChannelNotFound
+	}
+	return string(m[1]), nil
+}
+
+func GetChannelFeed(ctx context.Context, channel string, opts *Options) ([]FeedEntry, error) {
+	return NewClient(opts).GetChannelFeed(ctx, channel)
+}
+
+func (c *Client) GetChannelFeed(ctx context.Context, channel string) ([]FeedEntry, error) {
+	channelID, err := c.ResolveChannelID(ctx, channel)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", channelFeedURL+channelID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", c.opts.UserAgent)
+
+	resp, err := c.do(ctx, req)
+	if err != nil {
+		if errors.Is(err, ErrNoCaptionsFound) {
+			return nil, ErrChannelNotFound
+		}
+		return nil, fmt.Errorf("failed to get response: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var feed struct {
+		Entries []FeedEntry `xml:"entry"`
+	}
+	if err = xml.NewDecoder(resp.Body).Decode(&feed); err != nil {
+		return nil, fmt.Errorf("failed to parse channel feed: %w", err)
+	}
+	return feed.Entries, nil
+}
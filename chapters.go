@@ -0,0 +1,81 @@
+package caption
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const playerChaptersVersion = "1.2.0"
+
+type PlayerChapter struct {
+	Start float64 `json:"startTime"`
+	End   float64 `json:"endTime"`
+	Title string  `json:"title"`
+	Text  string  `json:"text,omitempty"`
+}
+
+type PlayerChapters struct {
+	Version  string          `json:"version"`
+	Title    string          `json:"title,omitempty"`
+	Chapters []PlayerChapter `json:"chapters"`
+}
+
+type ChapterExportOptions struct {
+	Chapters []Chapter
+	Topics   *TopicOptions
+	Text     bool
+}
+
+func (c *Caption) PlayerChapters(opts *ChapterExportOptions) *PlayerChapters {
+	if opts == nil {
+		opts = &ChapterExportOptions{}
+	}
+	chapters := opts.Chapters
+	if len(chapters) == 0 && c.Video != nil {
+		chapters = c.Video.Chapters()
+	}
+	if len(chapters) == 0 {
+		chapters = c.TopicChapters(opts.Topics)
+	}
+
+	doc := &PlayerChapters{Version: playerChaptersVersion, Chapters: []PlayerChapter{}}
+	if c.Video != nil {
+		doc.Title = c.Video.Title
+	}
+	if len(chapters) == 0 {
+		return doc
+	}
+	for _, section := range c.chapterSections(chapters) {
+		chapter := PlayerChapter{Start: roundMillis(section.Start), End: roundMillis(section.End), Title: section.Title}
+		if opts.Text {
+			chapter.Text = strings.TrimSpace(section.Summary)
+		}
+		doc.Chapters = append(doc.Chapters, chapter)
+	}
+	for i := range doc.Chapters[:len(doc.Chapters)-1] {
+		doc.Chapters[i].End = doc.Chapters[i+1].Start
+	}
+	return doc
+}
+
+func (c *Caption) WriteChapters(w io.Writer, opts *ChapterExportOptions) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(c.PlayerChapters(opts)); err != nil {
+		return fmt.Errorf("failed to marshal chapters: %w", err)
+	}
+	return nil
+}
+
+func (c *Caption) GetChapters(opts *ChapterExportOptions) string {
+	var result strings.Builder
+	_ = c.WriteChapters(&result, opts)
+	return result.String()
+}
+
+func (c *Caption) SaveChapters(filename string, opts *ChapterExportOptions) error {
+	return os.WriteFile(filename, []byte(c.GetChapters(opts)), 0644)
+}
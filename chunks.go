@@ -0,0 +1,109 @@
+package caption
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const defaultChunkTokens = 512
+
+type ChunkOptions struct {
+	MaxTokens int
+	Counter   TokenCounter
+}
+
+type Chunk struct {
+	VideoID string  `json:"videoId,omitempty"`
+	Index   int     `json:"chunkIndex"`
+	Start   float64 `json:"start"`
+	End     float64 `json:"end"`
+	Text    string  `json:"text"`
+	URL     string  `json:"url,omitempty"`
+	Tokens  int     `json:"tokens"`
+}
+
+func (c *Caption) Chunks(opts *ChunkOptions) []Chunk {
+	if opts == nil {
+		opts = &ChunkOptions{}
+	}
+	maxTokens := opts.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = defaultChunkTokens
+	}
+
+	var chunks []Chunk
+	var text []string
+	var current Chunk
+	flush := func() {
+		if len(text) == 0 {
+			return
+		}
+		current.VideoID = c.VideoID
+		current.Index = len(chunks)
+		current.Text = strings.Join(text, " ")
+		if c.VideoID != "" {
+			current.URL = SubtitleText{StartTime: current.Start}.URL(c.VideoID)
+		}
+		chunks = append(chunks, current)
+		text = nil
+		current = Chunk{}
+	}
+
+	for _, cue := range c.AnnotateTokens(opts.Counter) {
+		if len(text) > 0 && current.Tokens+cue.Tokens > maxTokens {
+			flush()
+		}
+		if len(text) == 0 {
+			current.Start = cue.StartTime
+		}
+		text = append(text, strings.ReplaceAll(cue.Text, "\n", " "))
+		current.End = cue.EndTime
+		current.Tokens += cue.Tokens
+	}
+	flush()
+	return chunks
+}
+
+func (c *Caption) WriteChunks(w io.Writer, opts *ChunkOptions) error {
+	enc := json.NewEncoder(w)
+	for _, chunk := range c.Chunks(opts) {
+		if err := enc.Encode(chunk); err != nil {
+			return fmt.Errorf("failed to marshal chunk: %w", err)
+		}
+	}
+	return nil
+}
+
+func (c *Caption) SaveChunks(filename string, opts *ChunkOptions) error {
+	return writeFileWith(filename, nil, func(w io.Writer) error {
+		return c.WriteChunks(w, opts)
+	})
+}
+
+func (c *Caption) SaveChunkFiles(dir string, opts *ChunkOptions) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	prefix := c.VideoID
+	if prefix == "" {
+		prefix = "chunk"
+	}
+	for _, chunk := range c.Chunks(opts) {
+		base := filepath.Join(dir, fmt.Sprintf("%s-%04d", prefix, chunk.Index))
+		if err := os.WriteFile(base+".txt", []byte(chunk.Text+"\n"), 0644); err != nil {
+			return err
+		}
+		metadata, err := json.MarshalIndent(chunk, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal chunk metadata: %w", err)
+		}
+		if err = os.WriteFile(base+".json", metadata, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package caption
+
+import (
+	"net/http"
+	"time"
+)
+
+type Client struct {
+	opts       *Options
+	httpClient *http.Client
+	breaker    *circuitBreaker
+	throttle   *adaptiveThrottle
+	players    *playerCache
+	versions   *versionCache
+	usage      *usageCounter
+	limiter    *rateLimiter
+	life       *lifecycle
+}
+
+func NewClient(opts *Options) *Client {
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+	return &Client{
+		opts:       opts,
+		httpClient: newHTTPClient(opts),
+		breaker:    newCircuitBreaker(opts),
+		throttle:   newAdaptiveThrottle(opts.Adaptive, opts.clock()),
+		players:    newPlayerCache(opts.clock()),
+		versions:   newVersionCache(),
+		usage:      newUsageCounter(opts.clock()),
+		limiter:    newRateLimiter(opts.RateLimit),
+		life:       newLifecycle(),
+	}
+}
+
+func (c *Client) Options() Options {
+	return *c.opts
+}
+
+func (c *Client) With(opts *Options) *Client {
+	clone := *c
+	if opts != nil {
+		clone.opts = opts
+		if opts.RateLimit != c.opts.RateLimit {
+			clone.limiter = newRateLimiter(opts.RateLimit)
+		}
+	}
+	return &clone
+}
+
+func (c *Client) observe(req *http.Request, resp *http.Response, elapsed time.Duration, err error) {
+	if c.opts.Metrics == nil {
+		return
+	}
+	m := RequestMetric{
+		Method:   req.Method,
+		Host:     req.URL.Host,
+		Duration: elapsed,
+		Err:      err,
+	}
+	if resp != nil {
+		m.StatusCode = resp.StatusCode
+	}
+	c.opts.Metrics.RequestCompleted(m)
+}
@@ -0,0 +1,102 @@
+package caption
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type Rand interface {
+	Float64() float64
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+var SystemClock Clock = systemClock{}
+
+type systemRand struct{}
+
+func (systemRand) Float64() float64 {
+	return rand.Float64()
+}
+
+func (o *Options) clock() Clock {
+	if o.Clock != nil {
+		return o.Clock
+	}
+	return SystemClock
+}
+
+func (o *Options) rand() Rand {
+	if o.Rand != nil {
+		return o.Rand
+	}
+	return systemRand{}
+}
+
+func sleep(ctx context.Context, clock Clock, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-clock.After(d):
+		return nil
+	}
+}
+
+type clockTimer struct {
+	clock Clock
+	c     <-chan time.Time
+}
+
+func (t *clockTimer) Start(d time.Duration) {
+	t.c = t.clock.After(d)
+}
+
+func (t *clockTimer) Stop() {}
+
+func (t *clockTimer) C() <-chan time.Time {
+	return t.c
+}
+
+type jitterBackOff struct {
+	backoff.BackOff
+	factor float64
+	rand   Rand
+}
+
+func (b *jitterBackOff) NextBackOff() time.Duration {
+	d := b.BackOff.NextBackOff()
+	if d == backoff.Stop {
+		return d
+	}
+	delta := b.factor * float64(d)
+	return time.Duration(float64(d) - delta + b.rand.Float64()*2*delta)
+}
+
+func (o *Options) backOff() backoff.BackOff {
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = time.Duration(o.MaxRetries) * 10 * time.Second
+	b.Clock = o.clock()
+	factor := b.RandomizationFactor
+	b.RandomizationFactor = 0
+	b.Reset()
+	return &jitterBackOff{BackOff: b, factor: factor, rand: o.rand()}
+}
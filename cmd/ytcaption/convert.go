@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	caption "github.com/lincaiyong/youtube-caption"
+)
+
+func runConvert(args []string) error {
+	fs := flag.NewFlagSet("convert", flag.ExitOnError)
+	from := fs.String("from", "", "input format to pick up from directories (default: every parseable file)")
+	to := fs.String("to", "", "output format: "+exportFormats)
+	out := fs.String("out", "", "output directory (default: next to each input)")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if *to == "" || len(positional) == 0 {
+		return errors.New("convert: --to and at least one file or directory are required")
+	}
+	toFormat, err := caption.ParseFormat(*to)
+	if err != nil {
+		return err
+	}
+	var fromFormat caption.Format
+	if *from != "" {
+		if fromFormat, err = caption.ParseFormat(*from); err != nil {
+			return err
+		}
+	}
+	if *out != "" {
+		if err = os.MkdirAll(*out, 0755); err != nil {
+			return err
+		}
+	}
+
+	var files []string
+	for _, arg := range positional {
+		info, err := os.Stat(arg)
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			files = append(files, arg)
+			continue
+		}
+		entries, err := os.ReadDir(arg)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			if entry.IsDir() || name == caption.ManifestFile {
+				continue
+			}
+			if fromFormat != "" && !strings.HasSuffix(strings.ToLower(name), fromFormat.Ext()) {
+				continue
+			}
+			if fromFormat == "" && !parseable(name) {
+				continue
+			}
+			files = append(files, filepath.Join(arg, name))
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	failed := 0
+	for _, src := range files {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		dst := convertedName(src, toFormat)
+		if *out != "" {
+			dst = filepath.Join(*out, filepath.Base(dst))
+		}
+		if dst == src {
+			continue
+		}
+		if err := caption.ConvertFileContext(ctx, src, dst); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", src, err)
+			failed++
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "%s -> %s\n", src, dst)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d files failed to convert", failed, len(files))
+	}
+	return nil
+}
+
+func parseable(name string) bool {
+	name = strings.ToLower(name)
+	for _, f := range []caption.Format{caption.FormatCues, caption.FormatJSON, caption.FormatSRT, caption.FormatVTT, caption.FormatXLIFF, caption.FormatTMX} {
+		if strings.HasSuffix(name, f.Ext()) {
+			return true
+		}
+	}
+	return false
+}
+
+func convertedName(src string, to caption.Format) string {
+	base := src
+	if strings.HasSuffix(strings.ToLower(base), caption.FormatCues.Ext()) {
+		base = base[:len(base)-len(caption.FormatCues.Ext())]
+	} else {
+		base = strings.TrimSuffix(base, filepath.Ext(base))
+	}
+	return base + to.Ext()
+}
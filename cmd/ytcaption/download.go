@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"time"
+
+	caption "github.com/lincaiyong/youtube-caption"
+)
+
+func runDownload(args []string) error {
+	fs := flag.NewFlagSet("download", flag.ExitOnError)
+	optFlags := addOptionFlags(fs)
+	format := fs.String("format", "srt", "output format: "+exportFormats)
+	output := fs.String("output", "", `output file (single video only), or "-" for stdout`)
+	dir := fs.String("dir", ".", "output directory")
+	sdh := fs.Bool("sdh", true, "keep sound cues and speaker labels (--sdh=false strips them)")
+	manifest := fs.Bool("manifest", false, "record sha256 checksums and track info in manifest.json next to the exports")
+	tts := fs.Bool("tts", false, "spell out numbers and expand abbreviations for text-to-speech")
+	lyrics := fs.Bool("lyrics", false, "keep only ♪-marked lyric lines, without the markers (pair with --format lrc)")
+	addJSONFlag(fs)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if n := len(positional); n > 0 && positional[n-1] == "-" {
+		*output = "-"
+		positional = positional[:n-1]
+	}
+	if len(positional) == 0 {
+		return errors.New("download: at least one video ID or URL is required")
+	}
+	toStdout := *output == "-"
+	if *output != "" && !toStdout && len(positional) > 1 {
+		return errors.New("download: --output can only be used with a single video")
+	}
+
+	if toStdout {
+		jsonOutput = os.Stderr
+	}
+
+	f, err := caption.ParseFormat(*format)
+	if err != nil {
+		return err
+	}
+	opts, err := optFlags.options(fs)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	client := caption.NewClient(opts)
+
+	failed := 0
+	for _, input := range positional {
+		videoID, err := caption.ExtractVideoID(input)
+		if err != nil {
+			reportFailure(input, "", stageResolve, err)
+			failed++
+			continue
+		}
+		videoCtx, cancel := withTimeout(ctx, opts.Timeout)
+		c, err := client.Download(videoCtx, videoID)
+		cancel()
+		if err != nil {
+			reportFailure(input, videoID, stageDownload, err)
+			failed++
+			continue
+		}
+		if *lyrics {
+			c = c.Lyrics()
+		}
+		c = c.SDH(*sdh)
+		if *tts {
+			c = c.NormalizeForTTS()
+		}
+
+		if toStdout {
+			if err = c.Write(os.Stdout, f); err != nil {
+				reportFailure(input, videoID, stageWrite, err)
+				return reportedError{err}
+			}
+			reportSuccess(input, videoID, "")
+			continue
+		}
+
+		filename := *output
+		if filename == "" {
+			filename = filepath.Join(*dir, videoID+f.Ext())
+		}
+		if err = writeCaptionFile(c, filename, f, *manifest); err != nil {
+			reportFailure(input, videoID, stageWrite, err)
+			failed++
+			continue
+		}
+		reportSuccess(input, videoID, filename)
+	}
+	if failed > 0 {
+		return reportedError{fmt.Errorf("%d of %d downloads failed", failed, len(positional))}
+	}
+	return nil
+}
+
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+const exportFormats = "srt, vtt, txt, json, cues, md, html, ttml, ass, screenplay, chunks, xliff, tmx, lrc, edl, chapters, zip"
+
+func writeCaptionFile(c *caption.Caption, filename string, format caption.Format, manifest bool) error {
+	return c.SaveWithOptions(filename, format, &caption.SaveOptions{Atomic: true, Manifest: manifest})
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+)
+
+const usage = `Usage: ytcaption <command> [flags] [args]
+
+Commands:
+  download      Download captions for one or more videos
+  list-tracks   List the caption tracks available for a video
+  search        Search downloaded transcripts or a single video
+  watch         Poll a channel and download captions for new uploads
+  site          Generate a static transcript site from downloaded captions
+  replace       Apply a regex replacement to every downloaded transcript
+  convert       Convert subtitle files between formats
+
+Run "ytcaption <command> -h" for command flags.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch cmd, args := os.Args[1], os.Args[2:]; cmd {
+	case "download":
+		err = runDownload(args)
+	case "list-tracks":
+		err = runListTracks(args)
+	case "search":
+		err = runSearch(args)
+	case "watch":
+		err = runWatch(args)
+	case "site":
+		err = runSite(args)
+	case "replace":
+		err = runReplace(args)
+	case "convert":
+		err = runConvert(args)
+	case "-h", "--help", "help":
+		fmt.Print(usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", cmd, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		var reported reportedError
+		if !jsonMode {
+			fmt.Fprintf(os.Stderr, "ytcaption: %v\n", err)
+		} else if !errors.As(err, &reported) {
+			emit(record{Status: "error", Error: classifyError("", err)})
+		}
+		os.Exit(1)
+	}
+}
+
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
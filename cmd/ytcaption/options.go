@@ -0,0 +1,78 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	caption "github.com/lincaiyong/youtube-caption"
+)
+
+type optionFlags struct {
+	config    *string
+	lang      *string
+	kind      *string
+	timeout   *time.Duration
+	proxy     *string
+	cacheDir  *string
+	rateLimit *float64
+	region    *string
+	sanitize  *bool
+	warnings  *bool
+}
+
+func addOptionFlags(fs *flag.FlagSet) *optionFlags {
+	return &optionFlags{
+		config:    fs.String("config", "", "config file (default $YTCAPTION_CONFIG or <user config dir>/ytcaption/config.json)"),
+		lang:      fs.String("lang", "en", "caption language code, or a comma-separated fallback chain"),
+		kind:      fs.String("kind", "asr", "caption kind (asr for auto-generated, empty for manual)"),
+		timeout:   fs.Duration("timeout", 30*time.Second, "per-video timeout"),
+		proxy:     fs.String("proxy", "", "HTTP(S) proxy URL"),
+		cacheDir:  fs.String("cache-dir", "", "directory for cached caption downloads"),
+		rateLimit: fs.Float64("rate-limit", 0, "maximum requests per second (0 for unlimited)"),
+		region:    fs.String("region", "", "two-letter region code sent as gl (e.g. DE)"),
+		sanitize:  fs.Bool("sanitize", false, "repair out-of-order events and out-of-range segment offsets"),
+		warnings:  fs.Bool("warnings", false, "print non-fatal warnings such as track fallbacks and skipped events"),
+	}
+}
+
+func (f *optionFlags) options(fs *flag.FlagSet) (*caption.Options, error) {
+	opts, err := caption.LoadOptions(*f.config)
+	if err != nil {
+		return nil, err
+	}
+	fs.Visit(func(fl *flag.Flag) {
+		switch fl.Name {
+		case "lang":
+			langs := strings.Split(*f.lang, ",")
+			opts.Language = strings.TrimSpace(langs[0])
+			opts.Languages = nil
+			for _, lang := range langs[1:] {
+				opts.Languages = append(opts.Languages, strings.TrimSpace(lang))
+			}
+		case "kind":
+			opts.Kind = *f.kind
+		case "timeout":
+			opts.Timeout = *f.timeout
+		case "proxy":
+			opts.Proxy = *f.proxy
+		case "cache-dir":
+			opts.CacheDir = *f.cacheDir
+		case "rate-limit":
+			opts.RateLimit = *f.rateLimit
+		case "region":
+			opts.Region = *f.region
+		case "sanitize":
+			opts.Sanitize = *f.sanitize
+		case "warnings":
+			if *f.warnings {
+				opts.OnWarning = func(videoID string, w caption.Warning) {
+					fmt.Fprintf(os.Stderr, "%s: warning: %s\n", videoID, w)
+				}
+			}
+		}
+	})
+	return opts, nil
+}
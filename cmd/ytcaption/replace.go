@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	caption "github.com/lincaiyong/youtube-caption"
+)
+
+func runReplace(args []string) error {
+	fs := flag.NewFlagSet("replace", flag.ExitOnError)
+	dir := fs.String("dir", ".", "directory of downloaded transcripts (json, cues, srt, vtt, xliff, tmx)")
+	dryRun := fs.Bool("dry-run", false, "print the changes as a diff without rewriting any file")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 2 {
+		return errors.New("replace: a pattern and a replacement are required")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	replacements, err := caption.ReplaceAllContext(ctx, *dir, positional[0], positional[1], &caption.ReplaceOptions{DryRun: *dryRun})
+	files := 0
+	for i, r := range replacements {
+		if i == 0 || replacements[i-1].File != r.File {
+			fmt.Printf("--- %s\n+++ %s\n", r.File, r.File)
+			files++
+		}
+		fmt.Print(r)
+	}
+	if err != nil {
+		return err
+	}
+	verb := "replaced"
+	if *dryRun {
+		verb = "would replace"
+	}
+	fmt.Fprintf(os.Stderr, "%s %d cues in %d files\n", verb, len(replacements), files)
+	return nil
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+
+	caption "github.com/lincaiyong/youtube-caption"
+)
+
+const (
+	stageResolve  = "resolve"
+	stageDownload = "download"
+	stageWrite    = "write"
+)
+
+var (
+	jsonMode   bool
+	jsonOutput io.Writer = os.Stdout
+)
+
+func addJSONFlag(fs *flag.FlagSet) {
+	fs.BoolVar(&jsonMode, "json", false, "emit structured JSON results and errors")
+}
+
+type reportedError struct {
+	error
+}
+
+type errorInfo struct {
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	Stage     string `json:"stage,omitempty"`
+	Retryable bool   `json:"retryable"`
+}
+
+type record struct {
+	Status  string     `json:"status"`
+	Input   string     `json:"input,omitempty"`
+	VideoID string     `json:"videoId,omitempty"`
+	File    string     `json:"file,omitempty"`
+	Error   *errorInfo `json:"error,omitempty"`
+}
+
+func classifyError(stage string, err error) *errorInfo {
+	info := &errorInfo{Code: "unknown", Message: err.Error(), Stage: stage, Retryable: caption.IsRetryable(err)}
+	var netErr net.Error
+	var stageErr *caption.StageError
+	switch {
+	case errors.Is(err, caption.ErrInvalidVideoID):
+		info.Code = "invalid_video_id"
+	case errors.Is(err, caption.ErrNoCaptionsFound):
+		info.Code = "no_captions"
+	case errors.Is(err, caption.ErrLiveInProgress):
+		info.Code = "live_in_progress"
+		info.Retryable = true
+	case errors.Is(err, caption.ErrNotYetAvailable):
+		info.Code = "not_yet_available"
+		info.Retryable = true
+	case errors.Is(err, caption.ErrRegionBlocked):
+		info.Code = "region_blocked"
+	case errors.Is(err, caption.ErrLimitExceeded):
+		info.Code = "limit_exceeded"
+	case errors.Is(err, caption.ErrChannelNotFound):
+		info.Code = "channel_not_found"
+	case errors.Is(err, caption.ErrRateLimited):
+		info.Code = "rate_limited"
+		info.Retryable = true
+	case errors.Is(err, caption.ErrCircuitOpen):
+		info.Code = "circuit_open"
+		info.Retryable = true
+	case errors.Is(err, caption.ErrClientClosed):
+		info.Code = "client_closed"
+		info.Retryable = true
+	case errors.Is(err, context.DeadlineExceeded):
+		info.Code = "timeout"
+		info.Retryable = true
+	case errors.Is(err, context.Canceled):
+		info.Code = "canceled"
+	case errors.As(err, &netErr):
+		info.Code = "network"
+		info.Retryable = true
+	case errors.Is(err, caption.ErrParse):
+		info.Code = "parse_failed"
+	case errors.As(err, &stageErr) && stageErr.StatusCode != 0:
+		info.Code = fmt.Sprintf("http_%d", stageErr.StatusCode)
+	case stage == stageWrite:
+		info.Code = "write_failed"
+	}
+	return info
+}
+
+func emit(r record) {
+	data, _ := json.Marshal(r)
+	_, _ = fmt.Fprintf(jsonOutput, "%s\n", data)
+}
+
+func reportSuccess(input, videoID, file string) {
+	if jsonMode {
+		emit(record{Status: "ok", Input: input, VideoID: videoID, File: file})
+		return
+	}
+	if file != "" {
+		fmt.Fprintf(os.Stderr, "%s: saved %s\n", videoID, file)
+	}
+}
+
+func reportFailure(input, videoID, stage string, err error) {
+	if jsonMode {
+		emit(record{Status: "error", Input: input, VideoID: videoID, Error: classifyError(stage, err)})
+		return
+	}
+	name := videoID
+	if name == "" {
+		name = input
+	}
+	fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	caption "github.com/lincaiyong/youtube-caption"
+)
+
+func runSearch(args []string) error {
+	fs := flag.NewFlagSet("search", flag.ExitOnError)
+	dir := fs.String("dir", "", "directory of downloaded JSON transcripts")
+	video := fs.String("video", "", "download and search a single video")
+	edl := fs.String("edl", "", "also write the matches as a CMX3600 EDL rough cut to this file")
+	handles := fs.Duration("handles", 0, "padding added before and after each EDL event")
+	optFlags := addOptionFlags(fs)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		return errors.New("search: a search term is required")
+	}
+	if (*dir == "") == (*video == "") {
+		return errors.New("search: exactly one of --dir or --video is required")
+	}
+	query := strings.Join(positional, " ")
+
+	idx := caption.NewIndex()
+	if *video != "" {
+		videoID, err := caption.ExtractVideoID(*video)
+		if err != nil {
+			return err
+		}
+		opts, err := optFlags.options(fs)
+		if err != nil {
+			return err
+		}
+		c, err := caption.DownloadWithOptions(videoID, opts)
+		if err != nil {
+			return err
+		}
+		idx.Add(videoID, c)
+	} else {
+		files, err := filepath.Glob(filepath.Join(*dir, "*.json"))
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			c, err := caption.LoadFromFile(file)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", file, err)
+				continue
+			}
+			idx.Add(strings.TrimSuffix(filepath.Base(file), ".json"), c)
+		}
+	}
+
+	matches := idx.Search(query)
+	if *edl != "" {
+		f, err := os.Create(*edl)
+		if err != nil {
+			return err
+		}
+		err = caption.WriteEDL(f, caption.EDLEventsFromMatches(matches), &caption.EDLOptions{Title: query, Handles: *handles})
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, m := range matches {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", m.VideoID, formatTimestamp(m.Cue.StartTime), m.Cue.Text)
+	}
+	return tw.Flush()
+}
+
+func formatTimestamp(seconds float64) string {
+	t := time.Duration(max(seconds, 0)) * time.Second
+	return fmt.Sprintf("%02d:%02d:%02d", int(t.Hours()), int(t.Minutes())%60, int(t.Seconds())%60)
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/lincaiyong/youtube-caption/site"
+)
+
+func runSite(args []string) error {
+	fs := flag.NewFlagSet("site", flag.ExitOnError)
+	dir := fs.String("dir", ".", "directory of downloaded transcripts (json, cues, srt, vtt, zip bundles)")
+	out := fs.String("out", "site", "output directory")
+	title := fs.String("title", "", "site title")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return errors.New("site: unexpected arguments")
+	}
+	if err = site.Generate(*dir, *out, &site.Options{Title: *title}); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "wrote %s\n", *out)
+	return nil
+}
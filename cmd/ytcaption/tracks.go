@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	caption "github.com/lincaiyong/youtube-caption"
+)
+
+func runListTracks(args []string) error {
+	fs := flag.NewFlagSet("list-tracks", flag.ExitOnError)
+	optFlags := addOptionFlags(fs)
+	output := fs.String("output", "table", "output format: table, json, csv")
+	addJSONFlag(fs)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return errors.New("list-tracks: exactly one video ID or URL is required")
+	}
+
+	if jsonMode {
+		*output = "json"
+	}
+
+	videoID, err := caption.ExtractVideoID(positional[0])
+	if err != nil {
+		reportFailure(positional[0], "", stageResolve, err)
+		return reportedError{err}
+	}
+	opts, err := optFlags.options(fs)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), opts.Timeout)
+	defer cancel()
+	tracks, err := caption.GetAvailableTracksWithOptions(ctx, videoID, opts)
+	if err != nil {
+		reportFailure(positional[0], videoID, stageDownload, err)
+		return reportedError{err}
+	}
+
+	switch *output {
+	case "table":
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "LANGUAGE\tKIND\tNAME\tUNUSABLE")
+		for _, track := range tracks {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", track.LanguageCode, track.Kind, track.Name.SimpleText, track.UnusableReason)
+		}
+		return tw.Flush()
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(tracks)
+	case "csv":
+		w := csv.NewWriter(os.Stdout)
+		_ = w.Write([]string{"language", "kind", "name", "usable", "unusable_reason"})
+		for _, track := range tracks {
+			_ = w.Write([]string{track.LanguageCode, track.Kind, track.Name.SimpleText,
+				strconv.FormatBool(track.Usable), string(track.UnusableReason)})
+		}
+		w.Flush()
+		return w.Error()
+	default:
+		return fmt.Errorf("list-tracks: unsupported output %q", *output)
+	}
+}
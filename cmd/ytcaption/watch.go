@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"time"
+
+	caption "github.com/lincaiyong/youtube-caption"
+)
+
+func runWatch(args []string) error {
+	fs := flag.NewFlagSet("watch", flag.ExitOnError)
+	interval := fs.Duration("interval", time.Hour, "polling interval")
+	format := fs.String("format", "srt", "output format: "+exportFormats)
+	dir := fs.String("dir", ".", "output directory")
+	optFlags := addOptionFlags(fs)
+	addJSONFlag(fs)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return errors.New("watch: exactly one channel (@handle, URL, or channel ID) is required")
+	}
+	f, err := caption.ParseFormat(*format)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(*dir, 0755); err != nil {
+		return err
+	}
+
+	opts, err := optFlags.options(fs)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	client := caption.NewClient(opts)
+	channelID, err := client.ResolveChannelID(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "watching %s every %s\n", channelID, *interval)
+
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	for {
+		pollChannel(ctx, client, channelID, opts.Timeout, f, *dir)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func pollChannel(ctx context.Context, client *caption.Client, channelID string, timeout time.Duration, format caption.Format, dir string) {
+	entries, err := client.GetChannelFeed(ctx, channelID)
+	if err != nil {
+		reportFailure(channelID, "", stageResolve, err)
+		return
+	}
+	for _, entry := range entries {
+		filename := filepath.Join(dir, entry.VideoID+format.Ext())
+		if _, err := os.Stat(filename); err == nil {
+			continue
+		}
+		videoCtx, cancel := withTimeout(ctx, timeout)
+		c, err := client.Download(videoCtx, entry.VideoID)
+		cancel()
+		if err != nil {
+			reportFailure(entry.VideoID, entry.VideoID, stageDownload, err)
+			continue
+		}
+		if err = writeCaptionFile(c, filename, format, false); err != nil {
+			reportFailure(entry.VideoID, entry.VideoID, stageWrite, err)
+			continue
+		}
+		reportSuccess(entry.VideoID, entry.VideoID, filename)
+	}
+}
@@ -0,0 +1,77 @@
+package caption
+
+import (
+	"fmt"
+	"time"
+)
+
+type ConcatPart struct {
+	VideoID   string
+	Caption   *Caption
+	Offset    time.Duration
+	HasOffset bool
+}
+
+type ConcatOptions struct {
+	PartMarkers  bool
+	MarkerFormat string
+}
+
+func partDuration(c *Caption) float64 {
+	if c.Video != nil && c.Video.LengthSeconds > 0 {
+		return float64(c.Video.LengthSeconds)
+	}
+	var end float64
+	for sub := range c.Cues() {
+		if sub.EndTime > end {
+			end = sub.EndTime
+		}
+	}
+	return end
+}
+
+func Concat(parts []ConcatPart, opts *ConcatOptions) *Caption {
+	if opts == nil {
+		opts = &ConcatOptions{}
+	}
+	markerFormat := opts.MarkerFormat
+	if markerFormat == "" {
+		markerFormat = "[Part %d: %s]"
+	}
+
+	var subs []SubtitleText
+	var next float64
+	for i, part := range parts {
+		if part.Caption == nil {
+			continue
+		}
+		offset := next
+		if part.HasOffset || part.Offset != 0 {
+			offset = part.Offset.Seconds()
+		}
+
+		if opts.PartMarkers {
+			title := part.VideoID
+			if part.Caption.Video != nil && part.Caption.Video.Title != "" {
+				title = part.Caption.Video.Title
+			}
+			subs = append(subs, SubtitleText{
+				StartTime: offset,
+				EndTime:   offset,
+				Text:      fmt.Sprintf(markerFormat, i+1, title),
+			})
+		}
+		for _, sub := range part.Caption.GetSubtitleText() {
+			sub.StartTime += offset
+			sub.EndTime += offset
+			subs = append(subs, sub)
+		}
+		next = offset + partDuration(part.Caption)
+	}
+
+	result := (&Caption{}).withSubtitles(subs)
+	if len(parts) > 0 {
+		result.VideoID = parts[0].VideoID
+	}
+	return result
+}
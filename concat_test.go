@@ -0,0 +1,34 @@
+package caption
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConcatOffsets(t *testing.T) {
+	part := func(text string) *Caption {
+		return (&Caption{}).withSubtitles([]SubtitleText{{StartTime: 0, EndTime: 10, Text: text}})
+	}
+	tests := []struct {
+		name  string
+		parts []ConcatPart
+		want  []float64
+	}{
+		{"auto", []ConcatPart{{Caption: part("a")}, {Caption: part("b")}, {Caption: part("c")}}, []float64{0, 10, 20}},
+		{"explicit", []ConcatPart{{Caption: part("a"), Offset: time.Minute}, {Caption: part("b")}}, []float64{60, 70}},
+		{"explicit zero", []ConcatPart{{Caption: part("a")}, {Caption: part("b"), HasOffset: true}}, []float64{0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subs := Concat(tt.parts, nil).GetSubtitleText()
+			if len(subs) != len(tt.want) {
+				t.Fatalf("got %d cues, want %d", len(subs), len(tt.want))
+			}
+			for i, sub := range subs {
+				if sub.StartTime != tt.want[i] {
+					t.Errorf("cue %d starts at %v, want %v", i, sub.StartTime, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package caption
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const envPrefix = "YTCAPTION_"
+
+type Config struct {
+	Languages        []string          `json:"languages,omitempty"`
+	Kind             *string           `json:"kind,omitempty"`
+	Timeout          string            `json:"timeout,omitempty"`
+	MaxRetries       *int              `json:"maxRetries,omitempty"`
+	UserAgent        string            `json:"userAgent,omitempty"`
+	Concurrency      *int              `json:"concurrency,omitempty"`
+	Proxy            string            `json:"proxy,omitempty"`
+	CacheDir         string            `json:"cacheDir,omitempty"`
+	RateLimit        *float64          `json:"rateLimit,omitempty"`
+	Clients          []InnerTubeClient `json:"clients,omitempty"`
+	DiscoverVersions *bool             `json:"discoverVersions,omitempty"`
+	Cookies          string            `json:"cookies,omitempty"`
+	CookiesFile      string            `json:"cookiesFile,omitempty"`
+	OAuthToken       string            `json:"oauthToken,omitempty"`
+}
+
+func DefaultConfigPath() string {
+	if path := os.Getenv(envPrefix + "CONFIG"); path != "" {
+		return path
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "ytcaption", "config.json")
+}
+
+func LoadConfig(filename string) (*Config, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var cfg Config
+	if err = json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", filename, err)
+	}
+	if cfg.Timeout != "" {
+		if _, err = time.ParseDuration(cfg.Timeout); err != nil {
+			return nil, fmt.Errorf("invalid timeout in config %s: %w", filename, err)
+		}
+	}
+	if err = cfg.loadCookies(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func ConfigFromEnv() (*Config, error) {
+	var cfg Config
+	if v, ok := os.LookupEnv(envPrefix + "LANGUAGES"); ok {
+		for _, lang := range strings.Split(v, ",") {
+			if lang = strings.TrimSpace(lang); lang != "" {
+				cfg.Languages = append(cfg.Languages, lang)
+			}
+		}
+	}
+	if v, ok := os.LookupEnv(envPrefix + "KIND"); ok {
+		cfg.Kind = &v
+	}
+	if v := os.Getenv(envPrefix + "TIMEOUT"); v != "" {
+		if _, err := time.ParseDuration(v); err != nil {
+			return nil, fmt.Errorf("invalid %sTIMEOUT: %w", envPrefix, err)
+		}
+		cfg.Timeout = v
+	}
+	if v := os.Getenv(envPrefix + "MAX_RETRIES"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %sMAX_RETRIES: %w", envPrefix, err)
+		}
+		cfg.MaxRetries = &n
+	}
+	if v := os.Getenv(envPrefix + "CONCURRENCY"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %sCONCURRENCY: %w", envPrefix, err)
+		}
+		cfg.Concurrency = &n
+	}
+	if v := os.Getenv(envPrefix + "RATE_LIMIT"); v != "" {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %sRATE_LIMIT: %w", envPrefix, err)
+		}
+		cfg.RateLimit = &f
+	}
+	if v := os.Getenv(envPrefix + "DISCOVER_VERSIONS"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %sDISCOVER_VERSIONS: %w", envPrefix, err)
+		}
+		cfg.DiscoverVersions = &b
+	}
+	cfg.UserAgent = os.Getenv(envPrefix + "USER_AGENT")
+	cfg.Proxy = os.Getenv(envPrefix + "PROXY")
+	cfg.CacheDir = os.Getenv(envPrefix + "CACHE_DIR")
+	cfg.Cookies = os.Getenv(envPrefix + "COOKIES")
+	cfg.CookiesFile = os.Getenv(envPrefix + "COOKIES_FILE")
+	cfg.OAuthToken = os.Getenv(envPrefix + "OAUTH_TOKEN")
+	if err := cfg.loadCookies(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func (c *Config) loadCookies() error {
+	if c.CookiesFile == "" || c.Cookies != "" {
+		return nil
+	}
+	cookies, err := LoadCookiesFile(c.CookiesFile)
+	if err != nil {
+		return fmt.Errorf("failed to load cookies file %s: %w", c.CookiesFile, err)
+	}
+	c.Cookies = cookies
+	return nil
+}
+
+func (c *Config) Merge(other *Config) {
+	if other == nil {
+		return
+	}
+	if len(other.Languages) > 0 {
+		c.Languages = other.Languages
+	}
+	if other.Kind != nil {
+		c.Kind = other.Kind
+	}
+	if other.Timeout != "" {
+		c.Timeout = other.Timeout
+	}
+	if other.MaxRetries != nil {
+		c.MaxRetries = other.MaxRetries
+	}
+	if other.UserAgent != "" {
+		c.UserAgent = other.UserAgent
+	}
+	if other.Concurrency != nil {
+		c.Concurrency = other.Concurrency
+	}
+	if other.Proxy != "" {
+		c.Proxy = other.Proxy
+	}
+	if other.CacheDir != "" {
+		c.CacheDir = other.CacheDir
+	}
+	if other.RateLimit != nil {
+		c.RateLimit = other.RateLimit
+	}
+	if len(other.Clients) > 0 {
+		c.Clients = other.Clients
+	}
+	if other.DiscoverVersions != nil {
+		c.DiscoverVersions = other.DiscoverVersions
+	}
+	if other.Cookies != "" {
+		c.Cookies = other.Cookies
+		c.CookiesFile = other.CookiesFile
+	}
+	if other.OAuthToken != "" {
+		c.OAuthToken = other.OAuthToken
+	}
+}
+
+func (c *Config) Apply(opts *Options) {
+	if len(c.Languages) > 0 {
+		opts.Language = c.Languages[0]
+		opts.Languages = c.Languages[1:]
+	}
+	if c.Kind != nil {
+		opts.Kind = *c.Kind
+	}
+	if d, err := time.ParseDuration(c.Timeout); err == nil {
+		opts.Timeout = d
+	}
+	if c.MaxRetries != nil {
+		opts.MaxRetries = *c.MaxRetries
+	}
+	if c.UserAgent != "" {
+		opts.UserAgent = c.UserAgent
+	}
+	if c.Concurrency != nil {
+		opts.Concurrency = *c.Concurrency
+	}
+	if c.Proxy != "" {
+		opts.Proxy = c.Proxy
+	}
+	if c.CacheDir != "" {
+		opts.CacheDir = c.CacheDir
+	}
+	if c.RateLimit != nil {
+		opts.RateLimit = *c.RateLimit
+	}
+	if len(c.Clients) > 0 {
+		opts.Clients = c.Clients
+	}
+	if c.DiscoverVersions != nil {
+		opts.DiscoverVersions = *c.DiscoverVersions
+	}
+	if c.Cookies != "" {
+		opts.Cookies = c.Cookies
+	}
+	if c.OAuthToken != "" {
+		opts.OAuthToken = c.OAuthToken
+	}
+}
+
+func LoadOptions(configFile string) (*Options, error) {
+	cfg := &Config{}
+	if configFile == "" {
+		configFile = DefaultConfigPath()
+		if fileCfg, err := LoadConfig(configFile); err == nil {
+			cfg.Merge(fileCfg)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	} else {
+		fileCfg, err := LoadConfig(configFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Merge(fileCfg)
+	}
+
+	envCfg, err := ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	cfg.Merge(envCfg)
+
+	opts := DefaultOptions()
+	cfg.Apply(opts)
+	return opts, nil
+}
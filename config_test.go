@@ -0,0 +1,43 @@
+package caption
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadOptionsCredentials(t *testing.T) {
+	dir := t.TempDir()
+	cookies := filepath.Join(dir, "cookies.txt")
+	jar := "# Netscape HTTP Cookie File\n.youtube.com\tTRUE\t/\tTRUE\t0\tSAPISID\tabc\n.example.com\tTRUE\t/\tTRUE\t0\tSID\tleak\n"
+	if err := os.WriteFile(cookies, []byte(jar), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	config := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(config, []byte(`{"cookies": "SID=file", "oauthToken": "file-token"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	opts, err := LoadOptions(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.Cookies != "SID=file" || opts.OAuthToken != "file-token" {
+		t.Errorf("config file gave cookies %q, token %q", opts.Cookies, opts.OAuthToken)
+	}
+
+	t.Setenv(envPrefix+"COOKIES_FILE", cookies)
+	t.Setenv(envPrefix+"OAUTH_TOKEN", "env-token")
+	opts, err = LoadOptions(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.Cookies != "SAPISID=abc" || opts.OAuthToken != "env-token" {
+		t.Errorf("environment gave cookies %q, token %q", opts.Cookies, opts.OAuthToken)
+	}
+
+	t.Setenv(envPrefix+"COOKIES_FILE", filepath.Join(dir, "missing.txt"))
+	if _, err = LoadOptions(config); err == nil {
+		t.Error("missing cookies file was not reported")
+	}
+}
@@ -0,0 +1,50 @@
+package cuesink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	caption "github.com/lincaiyong/youtube-caption"
+)
+
+type PublishFunc func(ctx context.Context, subject string, key string, data []byte) error
+
+type Message struct {
+	VideoID string  `json:"videoId"`
+	ID      string  `json:"id"`
+	Start   float64 `json:"start"`
+	End     float64 `json:"end"`
+	Text    string  `json:"text"`
+}
+
+type Sink struct {
+	publish PublishFunc
+	subject func(videoID string) string
+}
+
+func New(publish PublishFunc, subject string) *Sink {
+	return &Sink{publish: publish, subject: func(string) string { return subject }}
+}
+
+func NewPerVideo(publish PublishFunc, prefix string) *Sink {
+	return &Sink{publish: publish, subject: func(videoID string) string { return prefix + videoID }}
+}
+
+func (s *Sink) Send(ctx context.Context, videoID string, cue caption.SubtitleText) error {
+	msg := Message{
+		VideoID: videoID,
+		ID:      cue.ID(videoID),
+		Start:   cue.StartTime,
+		End:     cue.EndTime,
+		Text:    cue.Text,
+	}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cue: %w", err)
+	}
+	if err = s.publish(ctx, s.subject(videoID), videoID, data); err != nil {
+		return fmt.Errorf("failed to publish cue %s: %w", msg.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package objectstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+
+	caption "github.com/lincaiyong/youtube-caption"
+)
+
+type OpenWriterFunc func(ctx context.Context, key string) (io.WriteCloser, error)
+
+func Uploader(open OpenWriterFunc) caption.UploadFunc {
+	return func(ctx context.Context, key string, r io.Reader) error {
+		w, err := open(ctx, key)
+		if err != nil {
+			return fmt.Errorf("failed to open writer for %s: %w", key, err)
+		}
+		if _, err = io.Copy(w, r); err != nil {
+			_ = w.Close()
+			return fmt.Errorf("failed to write %s: %w", key, err)
+		}
+		return w.Close()
+	}
+}
+
+func UploadAll(ctx context.Context, c *caption.Caption, upload caption.UploadFunc, prefix string, formats ...caption.Format) error {
+	if len(formats) == 0 {
+		formats = []caption.Format{caption.FormatJSON, caption.FormatSRT, caption.FormatVTT, caption.FormatText}
+	}
+	for _, format := range formats {
+		key := path.Join(prefix, "captions"+format.Ext())
+		if c.VideoID != "" {
+			key = path.Join(prefix, c.VideoID+format.Ext())
+		}
+		if err := c.Upload(ctx, upload, key, format); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package render
+
+import (
+	caption "github.com/lincaiyong/youtube-caption"
+)
+
+func StyleFromASS(opts *caption.ASSOptions) *Style {
+	if opts == nil {
+		opts = &caption.ASSOptions{}
+	}
+	ass := opts.Style
+	if ass == nil {
+		ass = caption.DefaultASSStyle()
+	}
+	style := DefaultStyle()
+	if opts.PlayResX > 0 && opts.PlayResY > 0 {
+		style.Width, style.Height = opts.PlayResX, opts.PlayResY
+	}
+	if ass.FontSize > 0 {
+		style.Scale = max((ass.FontSize+style.Face.Height()/2)/style.Face.Height(), 1)
+	}
+	style.Color = ass.PrimaryColor
+	style.Outline, style.OutlineWidth, style.Box = nil, 0, nil
+	if ass.BorderStyle == caption.ASSBorderBox {
+		style.Box = ass.OutlineColor
+	} else if ass.Outline > 0 {
+		style.Outline, style.OutlineWidth = ass.OutlineColor, ass.Outline
+	}
+	style.MarginH = (ass.MarginL + ass.MarginR) / 2
+	style.MarginV = ass.MarginV
+	switch ass.Alignment {
+	case 1, 4, 7:
+		style.Align = AlignLeft
+	case 3, 6, 9:
+		style.Align = AlignRight
+	default:
+		style.Align = AlignCenter
+	}
+	if ass.Bold {
+		style.TextStyle |= caption.StyleBold
+	}
+	if ass.Italic {
+		style.TextStyle |= caption.StyleItalic
+	}
+	return style
+}
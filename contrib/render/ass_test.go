@@ -0,0 +1,38 @@
+package render
+
+import (
+	"image/color"
+	"testing"
+
+	caption "github.com/lincaiyong/youtube-caption"
+)
+
+func TestStyleFromASS(t *testing.T) {
+	style := StyleFromASS(nil)
+	def := caption.DefaultASSStyle()
+	if style.Width != 640 || style.Height != 360 || style.Scale != 3 || style.Color != def.PrimaryColor {
+		t.Errorf("default style = %+v", style)
+	}
+	if style.Outline != def.OutlineColor || style.OutlineWidth != 1 || style.Box != nil {
+		t.Errorf("outline border: outline %v/%d, box %v", style.Outline, style.OutlineWidth, style.Box)
+	}
+
+	yellow := color.NRGBA{R: 0xff, G: 0xff, A: 0xff}
+	box := color.NRGBA{A: 0x80}
+	style = StyleFromASS(&caption.ASSOptions{PlayResX: 1280, PlayResY: 720, Style: &caption.ASSStyle{
+		FontSize: 36, PrimaryColor: yellow, OutlineColor: box, BorderStyle: caption.ASSBorderBox, Outline: 2,
+		Italic: true, Alignment: 1, MarginL: 10, MarginR: 30, MarginV: 40,
+	}})
+	if style.Width != 1280 || style.Height != 720 || style.Scale != 4 || style.Color != yellow {
+		t.Errorf("size/colour = %dx%d scale %d colour %v", style.Width, style.Height, style.Scale, style.Color)
+	}
+	if style.Box != box || style.Outline != nil || style.Align != AlignLeft || style.MarginH != 20 || style.MarginV != 40 {
+		t.Errorf("box style = %+v", style)
+	}
+	if style.TextStyle != caption.StyleItalic {
+		t.Errorf("TextStyle = %v, want italic", style.TextStyle)
+	}
+	if img := Render(caption.SubtitleText{Text: "Hi"}, style); img.Bounds().Dx() != 1280 {
+		t.Errorf("rendered width = %d, want 1280", img.Bounds().Dx())
+	}
+}
@@ -0,0 +1,253 @@
+package render
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"os"
+
+	caption "github.com/lincaiyong/youtube-caption"
+)
+
+type Align int
+
+const (
+	AlignCenter Align = iota
+	AlignLeft
+	AlignRight
+)
+
+type Style struct {
+	Width        int
+	Height       int
+	Face         Face
+	Scale        int
+	Color        color.Color
+	Outline      color.Color
+	OutlineWidth int
+	Background   color.Color
+	Box          color.Color
+	Padding      int
+	MarginH      int
+	MarginV      int
+	Align        Align
+	TextStyle    caption.TextStyle
+}
+
+func DefaultStyle() *Style {
+	return &Style{
+		Width:        640,
+		Height:       360,
+		Face:         BasicFace,
+		Scale:        3,
+		Color:        color.White,
+		Outline:      color.Black,
+		OutlineWidth: 1,
+		Box:          color.NRGBA{A: 0xbf},
+		Padding:      6,
+		MarginH:      32,
+		MarginV:      24,
+	}
+}
+
+func (s *Style) withDefaults() *Style {
+	d := DefaultStyle()
+	if s == nil {
+		return d
+	}
+	out := *s
+	if out.Width <= 0 {
+		out.Width = d.Width
+	}
+	if out.Height <= 0 {
+		out.Height = d.Height
+	}
+	if out.Face == nil {
+		out.Face = d.Face
+	}
+	if out.Scale <= 0 {
+		out.Scale = d.Scale
+	}
+	if out.Color == nil {
+		out.Color = d.Color
+	}
+	return &out
+}
+
+type glyph struct {
+	r     rune
+	style caption.TextStyle
+}
+
+func cueRuns(cue caption.SubtitleText, base caption.TextStyle) []glyph {
+	spans := cue.Spans
+	if len(spans) == 0 {
+		spans = []caption.StyledSpan{{Text: cue.Text}}
+	}
+	var runs []glyph
+	for _, span := range spans {
+		for _, r := range span.Text {
+			runs = append(runs, glyph{r: r, style: span.Style | base})
+		}
+	}
+	return runs
+}
+
+func (s *Style) boldOffset() int {
+	return max(s.Scale/2, 1)
+}
+
+func (s *Style) advance(g glyph) int {
+	_, advance := s.Face.Glyph(g.r)
+	width := advance * s.Scale
+	if g.style&caption.StyleBold != 0 {
+		width += s.boldOffset()
+	}
+	return width
+}
+
+func (s *Style) measure(line []glyph) int {
+	width := 0
+	for _, g := range line {
+		width += s.advance(g)
+	}
+	return width
+}
+
+func (s *Style) layout(runs []glyph) [][]glyph {
+	maxWidth := s.Width - 2*s.MarginH
+	var lines [][]glyph
+	var line []glyph
+	width, lastSpace := 0, -1
+	for _, g := range runs {
+		if g.r == '\n' {
+			lines = append(lines, line)
+			line, width, lastSpace = nil, 0, -1
+			continue
+		}
+		if g.r == ' ' && len(line) == 0 {
+			continue
+		}
+		line = append(line, g)
+		width += s.advance(g)
+		if g.r == ' ' {
+			lastSpace = len(line) - 1
+		}
+		if width > maxWidth && lastSpace > 0 {
+			lines = append(lines, line[:lastSpace])
+			line = append([]glyph(nil), line[lastSpace+1:]...)
+			width, lastSpace = s.measure(line), -1
+		}
+	}
+	if len(line) > 0 || len(lines) == 0 {
+		lines = append(lines, line)
+	}
+	for i, l := range lines {
+		for len(l) > 0 && l[len(l)-1].r == ' ' {
+			l = l[:len(l)-1]
+		}
+		lines[i] = l
+	}
+	return lines
+}
+
+func Render(cue caption.SubtitleText, style *Style) *image.RGBA {
+	style = style.withDefaults()
+	img := image.NewRGBA(image.Rect(0, 0, style.Width, style.Height))
+	if style.Background != nil {
+		draw.Draw(img, img.Bounds(), image.NewUniform(style.Background), image.Point{}, draw.Src)
+	}
+	drawCue(img, cue, style)
+	return img
+}
+
+func Draw(dst draw.Image, cue caption.SubtitleText, style *Style) {
+	style = style.withDefaults()
+	bounds := dst.Bounds()
+	style.Width, style.Height = bounds.Dx(), bounds.Dy()
+	drawCue(dst, cue, style)
+}
+
+func drawCue(dst draw.Image, cue caption.SubtitleText, style *Style) {
+	lines := style.layout(cueRuns(cue, style.TextStyle))
+	origin := dst.Bounds().Min
+	lineHeight := style.Face.Height() * style.Scale
+	y := origin.Y + style.Height - style.MarginV - len(lines)*lineHeight - (len(lines)-1)*style.Padding
+
+	for _, line := range lines {
+		width := style.measure(line)
+		var x int
+		switch style.Align {
+		case AlignLeft:
+			x = style.MarginH
+		case AlignRight:
+			x = style.Width - style.MarginH - width
+		default:
+			x = (style.Width - width) / 2
+		}
+		x += origin.X
+		if style.Box != nil && len(line) > 0 {
+			box := image.Rect(x-style.Padding, y-style.Padding/2, x+width+style.Padding, y+lineHeight+style.Padding/2)
+			draw.Draw(dst, box, image.NewUniform(style.Box), image.Point{}, draw.Over)
+		}
+		if style.Outline != nil && style.OutlineWidth > 0 {
+			drawLine(dst, line, x, y, style, style.Outline, style.OutlineWidth)
+		}
+		drawLine(dst, line, x, y, style, style.Color, 0)
+		y += lineHeight + style.Padding
+	}
+}
+
+func drawLine(dst draw.Image, line []glyph, x, y int, style *Style, c color.Color, grow int) {
+	src := image.NewUniform(c)
+	height := style.Face.Height()
+	for _, g := range line {
+		mask, _ := style.Face.Glyph(g.r)
+		bounds := mask.Bounds()
+		for gy := bounds.Min.Y; gy < bounds.Max.Y; gy++ {
+			shear := 0
+			if g.style&caption.StyleItalic != 0 {
+				shear = (height - gy) * style.Scale / 4
+			}
+			for gx := bounds.Min.X; gx < bounds.Max.X; gx++ {
+				if mask.AlphaAt(gx, gy).A == 0 {
+					continue
+				}
+				px, py := x+gx*style.Scale+shear, y+gy*style.Scale
+				width := style.Scale
+				if g.style&caption.StyleBold != 0 {
+					width += style.boldOffset()
+				}
+				r := image.Rect(px-grow, py-grow, px+width+grow, py+style.Scale+grow)
+				draw.Draw(dst, r, src, image.Point{}, draw.Over)
+			}
+		}
+		x += style.advance(g)
+	}
+}
+
+func WritePNG(w io.Writer, cue caption.SubtitleText, style *Style) error {
+	if err := png.Encode(w, Render(cue, style)); err != nil {
+		return fmt.Errorf("failed to encode png: %w", err)
+	}
+	return nil
+}
+
+func SavePNG(filename string, cue caption.SubtitleText, style *Style) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	w := bufio.NewWriter(f)
+	if err = WritePNG(w, cue, style); err == nil {
+		err = w.Flush()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
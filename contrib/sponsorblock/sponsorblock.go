@@ -0,0 +1,79 @@
+package sponsorblock
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	caption "github.com/lincaiyong/youtube-caption"
+)
+
+const DefaultBaseURL = "https://sponsor.ajay.app"
+
+var DefaultCategories = []string{"sponsor", "selfpromo", "interaction"}
+
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+	Categories []string
+}
+
+func New() *Client {
+	return &Client{
+		BaseURL:    DefaultBaseURL,
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+		Categories: DefaultCategories,
+	}
+}
+
+type skipSegment struct {
+	Segment  [2]float64 `json:"segment"`
+	Category string     `json:"category"`
+}
+
+func (c *Client) Segments(ctx context.Context, videoID string) ([]caption.SkipSegment, error) {
+	categories, err := json.Marshal(c.Categories)
+	if err != nil {
+		return nil, err
+	}
+	query := url.Values{"videoID": {videoID}, "categories": {string(categories)}}
+	req, err := http.NewRequestWithContext(ctx, "GET", c.BaseURL+"/api/skipSegments?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("sponsorblock: unexpected status %s", resp.Status)
+	}
+
+	var segments []skipSegment
+	if err = json.NewDecoder(resp.Body).Decode(&segments); err != nil {
+		return nil, fmt.Errorf("failed to decode segments: %w", err)
+	}
+	result := make([]caption.SkipSegment, 0, len(segments))
+	for _, seg := range segments {
+		result = append(result, caption.SkipSegment{
+			Start:    time.Duration(seg.Segment[0] * float64(time.Second)),
+			End:      time.Duration(seg.Segment[1] * float64(time.Second)),
+			Category: seg.Category,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,291 @@
+package caption
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type Replacement struct {
+	File   string
+	Index  int
+	Start  float64
+	Before string
+	After  string
+}
+
+func (r Replacement) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "@@ %s #%d @@\n", formatVTTTime(secondsToDuration(r.Start)), r.Index+1)
+	for _, line := range strings.Split(r.Before, "\n") {
+		b.WriteString("-" + line + "\n")
+	}
+	for _, line := range strings.Split(r.After, "\n") {
+		b.WriteString("+" + line + "\n")
+	}
+	return b.String()
+}
+
+type ReplaceOptions struct {
+	DryRun bool
+}
+
+func (c *Caption) Replace(re *regexp.Regexp, replacement string) (*Caption, []Replacement) {
+	result := *c
+	result.Events = make([]CaptionEvent, len(c.Events))
+	copy(result.Events, c.Events)
+	var replacements []Replacement
+	index := 0
+	for i, event := range c.Events {
+		sub, ok := eventToSubtitle(event, c.Pens)
+		if !ok {
+			continue
+		}
+		text := re.ReplaceAllString(sub.Text, replacement)
+		if text != sub.Text {
+			replacements = append(replacements, Replacement{Index: index, Start: sub.StartTime, Before: sub.Text, After: text})
+			result.Events[i] = replaceEventText(event, c.Pens, sub.Text, text)
+		}
+		index++
+	}
+	return &result, replacements
+}
+
+func textSegments(event CaptionEvent, pens []CaptionPen) []int {
+	var indexes []int
+	for i, seg := range event.Segments {
+		if seg.UTF8 == "\n" {
+			continue
+		}
+		penID := seg.PenID
+		if penID == 0 {
+			penID = event.PenID
+		}
+		switch penFor(pens, penID).Ruby {
+		case rubyParen, rubyBefore, rubyAfter:
+			continue
+		}
+		indexes = append(indexes, i)
+	}
+	return indexes
+}
+
+func replaceEventText(event CaptionEvent, pens []CaptionPen, before, after string) CaptionEvent {
+	indexes := textSegments(event, pens)
+	var raw strings.Builder
+	for _, i := range indexes {
+		raw.WriteString(event.Segments[i].UTF8)
+	}
+	lead := raw.Len() - len(strings.TrimLeftFunc(raw.String(), unicode.IsSpace))
+
+	prefix := 0
+	for prefix < len(before) && prefix < len(after) && before[prefix] == after[prefix] {
+		prefix++
+	}
+	for prefix > 0 && prefix < len(before) && !utf8.RuneStart(before[prefix]) {
+		prefix--
+	}
+	suffix := 0
+	for suffix < len(before)-prefix && suffix < len(after)-prefix && before[len(before)-1-suffix] == after[len(after)-1-suffix] {
+		suffix++
+	}
+	for suffix > 0 && !utf8.RuneStart(before[len(before)-suffix]) {
+		suffix--
+	}
+	start, end := lead+prefix, lead+len(before)-suffix
+	middle := after[prefix : len(after)-suffix]
+
+	var overlapped, lengths []int
+	pos := 0
+	for _, i := range indexes {
+		n := len(event.Segments[i].UTF8)
+		switch {
+		case max(pos, start) < min(pos+n, end):
+			overlapped = append(overlapped, i)
+			lengths = append(lengths, min(pos+n, end)-max(pos, start))
+		case start == end && pos < start && start <= pos+n:
+			overlapped, lengths = []int{i}, []int{0}
+		case start == end && pos == start && n > 0 && (len(overlapped) == 0 || strings.HasPrefix(middle, " ")):
+			overlapped, lengths = []int{i}, []int{0}
+		}
+		pos += n
+	}
+	if len(overlapped) == 0 {
+		return event
+	}
+	parts := splitReplacement(middle, lengths)
+
+	segments := make([]CaptionSegment, len(event.Segments))
+	copy(segments, event.Segments)
+	pos = 0
+	for _, i := range indexes {
+		text := segments[i].UTF8
+		n := len(text)
+		if k := slices.Index(overlapped, i); k >= 0 {
+			from, to := min(max(start-pos, 0), n), min(max(end-pos, 0), n)
+			segments[i].UTF8 = text[:from] + parts[k] + text[to:]
+		}
+		pos += n
+	}
+	event.Segments = segments
+	return event
+}
+
+func splitReplacement(text string, lengths []int) []string {
+	parts := make([]string, len(lengths))
+	total := 0
+	for _, n := range lengths {
+		total += n
+	}
+	cut, covered := 0, 0
+	for k := range len(lengths) - 1 {
+		covered += lengths[k]
+		target := cut
+		if total > 0 {
+			target = max(cut, len(text)*covered/total)
+		}
+		next := nearestSpace(text, cut, target)
+		parts[k] = text[cut:next]
+		cut = next
+	}
+	parts[len(parts)-1] = text[cut:]
+	return parts
+}
+
+func nearestSpace(text string, from, target int) int {
+	best := -1
+	for i := from; i < len(text); i++ {
+		if text[i] == ' ' && (best < 0 || abs(i-target) < abs(best-target)) {
+			best = i
+		}
+	}
+	if best >= 0 {
+		return best
+	}
+	for target > from && target < len(text) && !utf8.RuneStart(text[target]) {
+		target--
+	}
+	return min(target, len(text))
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func ReplaceAll(dir, pattern, replacement string, opts *ReplaceOptions) ([]Replacement, error) {
+	return ReplaceAllContext(context.Background(), dir, pattern, replacement, opts)
+}
+
+func ReplaceAllContext(ctx context.Context, dir, pattern, replacement string, opts *ReplaceOptions) ([]Replacement, error) {
+	if opts == nil {
+		opts = &ReplaceOptions{}
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile pattern: %w", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := LoadManifest(dir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	var all []Replacement
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return all, err
+		}
+		if entry.IsDir() || entry.Name() == ManifestFile {
+			continue
+		}
+		format, err := detectFormat(entry.Name())
+		if err != nil || !corpusFormat(format) {
+			continue
+		}
+		filename := filepath.Join(dir, entry.Name())
+		c, err := LoadFileContext(ctx, filename)
+		if err != nil {
+			return all, fmt.Errorf("failed to load %s: %w", filename, err)
+		}
+		replaced, replacements := c.Replace(re, replacement)
+		if len(replacements) == 0 {
+			continue
+		}
+		for i := range replacements {
+			replacements[i].File = entry.Name()
+		}
+		all = append(all, replacements...)
+		if opts.DryRun {
+			continue
+		}
+
+		saveOpts := &SaveOptions{Atomic: true}
+		if manifest != nil {
+			saveOpts.Manifest = manifest.restore(entry.Name(), replaced)
+		}
+		if err = replaced.SaveWithContext(ctx, filename, format, saveOpts); err != nil {
+			return all, fmt.Errorf("failed to save %s: %w", filename, err)
+		}
+	}
+	return all, nil
+}
+
+func corpusFormat(format Format) bool {
+	switch format {
+	case FormatJSON, FormatCues, FormatSRT, FormatVTT, FormatXLIFF, FormatTMX:
+		return true
+	}
+	return false
+}
+
+func (m *Manifest) restore(file string, c *Caption) bool {
+	for _, entry := range m.Files {
+		if entry.File != file {
+			continue
+		}
+		if c.VideoID == "" {
+			c.VideoID = entry.VideoID
+		}
+		if c.Track == nil && (entry.Language != "" || entry.Kind != "" || entry.TrackName != "") {
+			c.Track = &CaptionTrack{LanguageCode: entry.Language, Kind: entry.Kind}
+			c.Track.Name.SimpleText = entry.TrackName
+		}
+		if entry.DownloadedAt != nil {
+			c.downloaded = *entry.DownloadedAt
+		}
+		return true
+	}
+	return false
+}
+
+func ConvertFile(src, dst string) error {
+	return ConvertFileContext(context.Background(), src, dst)
+}
+
+func ConvertFileContext(ctx context.Context, src, dst string) error {
+	format, err := detectFormat(dst)
+	if err != nil {
+		return err
+	}
+	c, err := LoadFileContext(ctx, src)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", src, err)
+	}
+	if err = c.SaveWithContext(ctx, dst, format, &SaveOptions{Atomic: true}); err != nil {
+		return fmt.Errorf("failed to save %s: %w", dst, err)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package caption
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func replaceSample() *Caption {
+	return &Caption{
+		Pens: []CaptionPen{{}, {Italic: 1}},
+		Events: []CaptionEvent{
+			{TStartMs: 0, Segments: []CaptionSegment{
+				{UTF8: "Jon", AcAsrConf: 200},
+				{UTF8: " Smyth", TOffsetMs: 400, AcAsrConf: 180},
+				{UTF8: " said", TOffsetMs: 900, AcAsrConf: 255},
+				{TOffsetMs: 1500},
+			}},
+			{TStartMs: 2000, PenID: 1, Segments: []CaptionSegment{
+				{UTF8: "hello", AcAsrConf: 230},
+				{UTF8: "\n"},
+				{UTF8: "world", TOffsetMs: 600, AcAsrConf: 240},
+			}},
+		},
+	}
+}
+
+func TestReplaceEditsSegmentsInPlace(t *testing.T) {
+	c := replaceSample()
+	replaced, replacements := c.Replace(regexp.MustCompile(`Jon Smyth`), "John Smith")
+	if len(replacements) != 1 || replacements[0].Index != 0 || replacements[0].After != "John Smith said" {
+		t.Fatalf("replacements = %+v", replacements)
+	}
+
+	want := []CaptionSegment{
+		{UTF8: "John", AcAsrConf: 200},
+		{UTF8: " Smith", TOffsetMs: 400, AcAsrConf: 180},
+		{UTF8: " said", TOffsetMs: 900, AcAsrConf: 255},
+		{TOffsetMs: 1500},
+	}
+	if got := replaced.Events[0].Segments; !reflect.DeepEqual(got, want) {
+		t.Errorf("matched segments = %+v, want %+v", got, want)
+	}
+	if !reflect.DeepEqual(replaced.Events[1], c.Events[1]) || !reflect.DeepEqual(replaced.Pens, c.Pens) {
+		t.Error("unmatched event or pens changed")
+	}
+	if c.Events[0].Segments[0].UTF8 != "Jon" {
+		t.Error("Replace modified the original caption")
+	}
+
+	tests := []struct {
+		pattern, replacement string
+		want                 []string
+	}{
+		{`Smyth `, "", []string{"Jon", " ", "said", ""}},
+		{`Jon`, "Jonathan", []string{"Jonathan", " Smyth", " said", ""}},
+		{`Smyth said`, "Smith replied", []string{"Jon", " Smith", " replied", ""}},
+		{`^`, "Mr ", []string{"Mr Jon", " Smyth", " said", ""}},
+	}
+	for _, tt := range tests {
+		replaced, _ := c.Replace(regexp.MustCompile(tt.pattern), tt.replacement)
+		var got []string
+		for _, seg := range replaced.Events[0].Segments {
+			got = append(got, seg.UTF8)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q -> %q: segments %q, want %q", tt.pattern, tt.replacement, got, tt.want)
+		}
+	}
+}
+
+func TestReplaceAllRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	c := replaceSample()
+	c.VideoID = "dQw4w9WgXcQ"
+	originals := map[string]string{}
+	for _, format := range []Format{FormatJSON, FormatSRT, FormatXLIFF} {
+		var b strings.Builder
+		if err := c.Write(&b, format); err != nil {
+			t.Fatal(err)
+		}
+		name := "captions" + format.Ext()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(b.String()), 0644); err != nil {
+			t.Fatal(err)
+		}
+		originals[name] = b.String()
+	}
+
+	replacements, err := ReplaceAll(dir, `Jon Smyth`, "John Smith", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(replacements) != 3 {
+		t.Errorf("got %d replacements, want one per file", len(replacements))
+	}
+
+	read := func(name string) string {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+	jsonWant := strings.NewReplacer(`"utf8": "Jon"`, `"utf8": "John"`, `"utf8": " Smyth"`, `"utf8": " Smith"`).Replace(originals["captions.json"])
+	if got := read("captions.json"); got != jsonWant {
+		t.Errorf("json:\n%s\nwant:\n%s", got, jsonWant)
+	}
+	srtWant := strings.Replace(originals["captions.srt"], "Jon Smyth", "John Smith", 1)
+	if got := read("captions.srt"); got != srtWant {
+		t.Errorf("srt:\n%s\nwant:\n%s", got, srtWant)
+	}
+
+	xliff := read("captions.xliff")
+	original := originals["captions.xliff"]
+	unmatched := original[strings.LastIndex(original, "<trans-unit"):strings.LastIndex(original, "</trans-unit>")]
+	if !strings.Contains(xliff, unmatched) {
+		t.Errorf("unmatched XLIFF unit changed:\n%s", xliff)
+	}
+	if !strings.Contains(xliff, "<source>Jon Smyth said</source>") || !strings.Contains(xliff, "<target>John Smith said</target>") {
+		t.Errorf("XLIFF source overwritten or target missing:\n%s", xliff)
+	}
+}
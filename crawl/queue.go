@@ -0,0 +1,223 @@
+package crawl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	caption "github.com/lincaiyong/youtube-caption"
+)
+
+type State string
+
+const (
+	StatePending State = "pending"
+	StateDone    State = "done"
+	StateFailed  State = "failed"
+	StateRetry   State = "retry"
+)
+
+const (
+	defaultMaxAttempts = 5
+	defaultRetryDelay  = time.Minute
+	maxRetryDelay      = 6 * time.Hour
+)
+
+type Job struct {
+	VideoID    string    `json:"videoId"`
+	State      State     `json:"state"`
+	Attempts   int       `json:"attempts"`
+	LastError  string    `json:"lastError,omitempty"`
+	RetryAfter time.Time `json:"retryAfter,omitzero"`
+	Added      time.Time `json:"added"`
+	Updated    time.Time `json:"updated"`
+}
+
+type Stats struct {
+	Pending int
+	Retry   int
+	Done    int
+	Failed  int
+}
+
+type Queue struct {
+	MaxAttempts int
+	RetryDelay  time.Duration
+	Clock       caption.Clock
+
+	store Store
+}
+
+func NewQueue(store Store) *Queue {
+	return &Queue{MaxAttempts: defaultMaxAttempts, RetryDelay: defaultRetryDelay, store: store}
+}
+
+func (q *Queue) Add(videoIDs ...string) error {
+	now := q.now()
+	for _, videoID := range videoIDs {
+		if _, err := q.store.Get(videoID); err == nil {
+			continue
+		} else if !errors.Is(err, ErrNotFound) {
+			return err
+		}
+		if err := q.store.Put(Job{VideoID: videoID, State: StatePending, Added: now, Updated: now}); err != nil {
+			return fmt.Errorf("failed to add job %s: %w", videoID, err)
+		}
+	}
+	return nil
+}
+
+func (q *Queue) Next() (*Job, time.Time, error) {
+	now := q.now()
+	if indexed, ok := q.store.(IndexedStore); ok {
+		return indexed.Ready(now)
+	}
+	jobs, err := q.store.List()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	var retry *Job
+	for _, job := range jobs {
+		switch job.State {
+		case StatePending:
+			return &job, time.Time{}, nil
+		case StateRetry:
+			if retry == nil || job.RetryAfter.Before(retry.RetryAfter) {
+				retry = &job
+			}
+		}
+	}
+	if retry != nil && retry.RetryAfter.After(now) {
+		return nil, retry.RetryAfter, nil
+	}
+	return retry, time.Time{}, nil
+}
+
+func (q *Queue) Done(videoID string) error {
+	return q.update(videoID, func(job *Job) {
+		job.State = StateDone
+		job.Attempts++
+		job.LastError = ""
+		job.RetryAfter = time.Time{}
+	})
+}
+
+func (q *Queue) Fail(videoID string, cause error) error {
+	return q.update(videoID, func(job *Job) {
+		job.Attempts++
+		job.LastError = cause.Error()
+		if permanent(cause) || job.Attempts >= q.MaxAttempts {
+			job.State = StateFailed
+			job.RetryAfter = time.Time{}
+			return
+		}
+		job.State = StateRetry
+		job.RetryAfter = q.now().Add(q.retryDelay(job.Attempts, cause))
+		var upcoming *caption.NotYetAvailableError
+		if errors.As(cause, &upcoming) && upcoming.ScheduledStart.After(job.RetryAfter) {
+			job.RetryAfter = upcoming.ScheduledStart
+		}
+	})
+}
+
+func (q *Queue) Stats() (Stats, error) {
+	jobs, err := q.store.List()
+	if err != nil {
+		return Stats{}, err
+	}
+	var stats Stats
+	for _, job := range jobs {
+		switch job.State {
+		case StatePending:
+			stats.Pending++
+		case StateRetry:
+			stats.Retry++
+		case StateDone:
+			stats.Done++
+		case StateFailed:
+			stats.Failed++
+		}
+	}
+	return stats, nil
+}
+
+func (q *Queue) Run(ctx context.Context, client *caption.Client, fn func(*caption.Caption) error) error {
+	for {
+		job, wake, err := q.Next()
+		if err != nil {
+			return err
+		}
+		if job == nil {
+			if wake.IsZero() {
+				return nil
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-clockOr(q.Clock).After(wake.Sub(q.now())):
+			}
+			continue
+		}
+
+		c, err := client.Download(ctx, job.VideoID)
+		if err == nil {
+			err = fn(c)
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			err = q.Fail(job.VideoID, err)
+		} else {
+			err = q.Done(job.VideoID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (q *Queue) now() time.Time {
+	return clockOr(q.Clock).Now()
+}
+
+func clockOr(clock caption.Clock) caption.Clock {
+	if clock == nil {
+		return caption.SystemClock
+	}
+	return clock
+}
+
+func (q *Queue) update(videoID string, fn func(*Job)) error {
+	job, err := q.store.Get(videoID)
+	if err != nil {
+		return fmt.Errorf("failed to load job %s: %w", videoID, err)
+	}
+	fn(&job)
+	job.Updated = q.now()
+	if err = q.store.Put(job); err != nil {
+		return fmt.Errorf("failed to save job %s: %w", videoID, err)
+	}
+	return nil
+}
+
+func (q *Queue) retryDelay(attempts int, cause error) time.Duration {
+	delay := q.RetryDelay
+	if delay <= 0 {
+		delay = defaultRetryDelay
+	}
+	if errors.Is(cause, caption.ErrRateLimited) || errors.Is(cause, caption.ErrCircuitOpen) {
+		delay *= 4
+	}
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRetryDelay)
+}
+
+func permanent(err error) bool {
+	return errors.Is(err, caption.ErrInvalidVideoID) ||
+		errors.Is(err, caption.ErrNoCaptionsFound) ||
+		errors.Is(err, caption.ErrRegionBlocked)
+}
@@ -0,0 +1,202 @@
+package crawl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	caption "github.com/lincaiyong/youtube-caption"
+)
+
+type PacingOptions struct {
+	Delay      time.Duration
+	Jitter     time.Duration
+	DailyQuota int
+	ResetHour  int
+	StatePath  string
+	Clock      caption.Clock
+	Rand       caption.Rand
+}
+
+type hostState struct {
+	Last        time.Time `json:"last"`
+	WindowStart time.Time `json:"windowStart"`
+	Count       int       `json:"count"`
+}
+
+type Scheduler struct {
+	opts  PacingOptions
+	mu    sync.Mutex
+	hosts map[string]*hostState
+}
+
+func NewScheduler(opts PacingOptions) (*Scheduler, error) {
+	s := &Scheduler{opts: opts, hosts: make(map[string]*hostState)}
+	if opts.StatePath == "" {
+		return s, nil
+	}
+	data, err := os.ReadFile(opts.StatePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pacing state: %w", err)
+	}
+	if err = json.Unmarshal(data, &s.hosts); err != nil {
+		return nil, fmt.Errorf("failed to parse pacing state %s: %w", opts.StatePath, err)
+	}
+	return s, nil
+}
+
+func (s *Scheduler) windowStart(t time.Time) time.Time {
+	start := time.Date(t.Year(), t.Month(), t.Day(), s.opts.ResetHour, 0, 0, 0, t.Location())
+	if start.After(t) {
+		start = start.AddDate(0, 0, -1)
+	}
+	return start
+}
+
+type reservation struct {
+	host   string
+	at     time.Time
+	last   time.Time
+	window time.Time
+}
+
+func (s *Scheduler) reserve(host string, now time.Time) reservation {
+	state, ok := s.hosts[host]
+	if !ok {
+		state = &hostState{}
+		s.hosts[host] = state
+	}
+
+	at := now
+	if !state.Last.IsZero() {
+		delay := s.opts.Delay
+		if s.opts.Jitter > 0 {
+			delay += s.jitter()
+		}
+		at = later(at, state.Last.Add(delay))
+	}
+	if s.opts.DailyQuota > 0 {
+		if window := s.windowStart(at); !window.Equal(state.WindowStart) {
+			state.WindowStart, state.Count = window, 0
+		}
+		if state.Count >= s.opts.DailyQuota {
+			at = state.WindowStart.AddDate(0, 0, 1)
+			state.WindowStart, state.Count = at, 0
+		}
+		state.Count++
+	}
+	r := reservation{host: host, at: at, last: state.Last, window: state.WindowStart}
+	state.Last = at
+	return r
+}
+
+func (s *Scheduler) release(r reservation) {
+	state := s.hosts[r.host]
+	if state.Last.Equal(r.at) {
+		state.Last = r.last
+	}
+	if s.opts.DailyQuota > 0 && state.WindowStart.Equal(r.window) && state.Count > 0 {
+		state.Count--
+	}
+}
+
+func (s *Scheduler) jitter() time.Duration {
+	if s.opts.Rand != nil {
+		return time.Duration(s.opts.Rand.Float64() * float64(s.opts.Jitter))
+	}
+	return rand.N(s.opts.Jitter)
+}
+
+func later(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+func (s *Scheduler) Wait(ctx context.Context, host string) error {
+	clock := clockOr(s.opts.Clock)
+	now := clock.Now()
+	s.mu.Lock()
+	r := s.reserve(host, now)
+	err := s.save()
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	wait := r.at.Sub(now)
+	if wait <= 0 {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		s.mu.Lock()
+		s.release(r)
+		_ = s.save()
+		s.mu.Unlock()
+		return ctx.Err()
+	case <-clock.After(wait):
+		return nil
+	}
+}
+
+func (s *Scheduler) save() error {
+	if s.opts.StatePath == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(s.hosts, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal pacing state: %w", err)
+	}
+	if err = os.MkdirAll(filepath.Dir(s.opts.StatePath), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.opts.StatePath), "."+filepath.Base(s.opts.StatePath)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	if _, err = tmp.Write(data); err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), s.opts.StatePath)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write pacing state: %w", err)
+	}
+	return nil
+}
+
+func (s *Scheduler) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &pacedTransport{scheduler: s, base: base}
+}
+
+type pacedTransport struct {
+	scheduler *Scheduler
+	base      http.RoundTripper
+}
+
+func (t *pacedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.scheduler.Wait(req.Context(), req.URL.Host); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
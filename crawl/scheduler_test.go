@@ -0,0 +1,110 @@
+package crawl
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type fixedRand float64
+
+func (r fixedRand) Float64() float64 { return float64(r) }
+
+type stalledClock struct {
+	*fakeClock
+}
+
+func (stalledClock) After(time.Duration) <-chan time.Time { return nil }
+
+func waitAt(t *testing.T, s *Scheduler, clock *fakeClock, want time.Time) {
+	t.Helper()
+	if err := s.Wait(context.Background(), "www.youtube.com"); err != nil {
+		t.Fatal(err)
+	}
+	if got := clock.Now(); !got.Equal(want) {
+		t.Fatalf("request went out at %v, want %v", got, want)
+	}
+}
+
+func TestSchedulerJitter(t *testing.T) {
+	clock := newFakeClock()
+	start := clock.Now()
+	s, err := NewScheduler(PacingOptions{Delay: 10 * time.Second, Jitter: 10 * time.Second, Clock: clock, Rand: fixedRand(0.5)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitAt(t, s, clock, start)
+	waitAt(t, s, clock, start.Add(15*time.Second))
+	clock.Advance(time.Minute)
+	waitAt(t, s, clock, start.Add(75*time.Second))
+}
+
+func TestSchedulerQuotaRollover(t *testing.T) {
+	clock := newFakeClock()
+	start := clock.Now()
+	s, err := NewScheduler(PacingOptions{DailyQuota: 2, ResetHour: 3, Clock: clock})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reset := time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)
+	waitAt(t, s, clock, start)
+	waitAt(t, s, clock, start)
+	waitAt(t, s, clock, reset)
+	waitAt(t, s, clock, reset)
+	waitAt(t, s, clock, reset.AddDate(0, 0, 1))
+}
+
+func TestSchedulerRestoresState(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "pacing.json")
+	clock := newFakeClock()
+	start := clock.Now()
+	opts := PacingOptions{Delay: time.Minute, DailyQuota: 2, ResetHour: 3, StatePath: path, Clock: clock}
+	s, err := NewScheduler(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitAt(t, s, clock, start)
+	waitAt(t, s, clock, start.Add(time.Minute))
+
+	s, err = NewScheduler(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitAt(t, s, clock, time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC))
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("state dir has %d entries, want only pacing.json", len(entries))
+	}
+}
+
+func TestSchedulerCancelReleasesQuota(t *testing.T) {
+	clock := newFakeClock()
+	start := clock.Now()
+	s, err := NewScheduler(PacingOptions{Delay: time.Hour, DailyQuota: 2, ResetHour: 3, Clock: clock})
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitAt(t, s, clock, start)
+
+	s.opts.Clock = stalledClock{clock}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err = s.Wait(ctx, "www.youtube.com"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Wait = %v, want context.Canceled", err)
+	}
+	if state := s.hosts["www.youtube.com"]; state.Count != 1 || !state.Last.Equal(start) {
+		t.Errorf("canceled wait kept its reservation: %+v", state)
+	}
+
+	s.opts.Clock = clock
+	waitAt(t, s, clock, start.Add(time.Hour))
+	waitAt(t, s, clock, time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC))
+}
@@ -0,0 +1,284 @@
+package crawl
+
+import (
+	"bufio"
+	"bytes"
+	"container/heap"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+var ErrNotFound = errors.New("job not found")
+
+const compactMinRecords = 1024
+
+type Store interface {
+	Get(videoID string) (Job, error)
+	Put(job Job) error
+	List() ([]Job, error)
+}
+
+type IndexedStore interface {
+	Store
+	Ready(now time.Time) (*Job, time.Time, error)
+}
+
+type jobKey struct {
+	at      time.Time
+	videoID string
+}
+
+type jobHeap []jobKey
+
+func (h jobHeap) Len() int { return len(h) }
+
+func (h jobHeap) Less(i, j int) bool {
+	if !h[i].at.Equal(h[j].at) {
+		return h[i].at.Before(h[j].at)
+	}
+	return h[i].videoID < h[j].videoID
+}
+
+func (h jobHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *jobHeap) Push(x any) { *h = append(*h, x.(jobKey)) }
+
+func (h *jobHeap) Pop() any {
+	old := *h
+	key := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return key
+}
+
+type MemoryStore struct {
+	mu      sync.Mutex
+	jobs    map[string]Job
+	pending jobHeap
+	retry   jobHeap
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{jobs: make(map[string]Job)}
+}
+
+func (s *MemoryStore) Get(videoID string) (Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[videoID]
+	if !ok {
+		return Job{}, ErrNotFound
+	}
+	return job, nil
+}
+
+func (s *MemoryStore) Put(job Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.put(job)
+	return nil
+}
+
+func (s *MemoryStore) put(job Job) {
+	s.jobs[job.VideoID] = job
+	switch job.State {
+	case StatePending:
+		heap.Push(&s.pending, jobKey{at: job.Added, videoID: job.VideoID})
+	case StateRetry:
+		heap.Push(&s.retry, jobKey{at: job.RetryAfter, videoID: job.VideoID})
+	}
+}
+
+func (s *MemoryStore) List() ([]Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	jobs := make([]Job, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		if !jobs[i].Added.Equal(jobs[j].Added) {
+			return jobs[i].Added.Before(jobs[j].Added)
+		}
+		return jobs[i].VideoID < jobs[j].VideoID
+	})
+	return jobs, nil
+}
+
+func (s *MemoryStore) Ready(now time.Time) (*Job, time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for len(s.pending) > 0 {
+		key := s.pending[0]
+		if job := s.jobs[key.videoID]; job.State == StatePending && job.Added.Equal(key.at) {
+			return &job, time.Time{}, nil
+		}
+		heap.Pop(&s.pending)
+	}
+	for len(s.retry) > 0 {
+		key := s.retry[0]
+		job := s.jobs[key.videoID]
+		if job.State != StateRetry || !job.RetryAfter.Equal(key.at) {
+			heap.Pop(&s.retry)
+			continue
+		}
+		if job.RetryAfter.After(now) {
+			return nil, job.RetryAfter, nil
+		}
+		return &job, time.Time{}, nil
+	}
+	return nil, time.Time{}, nil
+}
+
+type FileStore struct {
+	mu      sync.Mutex
+	path    string
+	memory  *MemoryStore
+	file    *os.File
+	records int
+}
+
+func OpenFileStore(path string) (*FileStore, error) {
+	s := &FileStore{path: path, memory: NewMemoryStore()}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read job store: %w", err)
+	}
+	compact, err := s.load(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse job store %s: %w", path, err)
+	}
+	if compact {
+		err = s.compact()
+	} else {
+		s.file, err = os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open job store: %w", err)
+	}
+	return s, nil
+}
+
+func (s *FileStore) load(data []byte) (bool, error) {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		var jobs []Job
+		if err := json.Unmarshal(trimmed, &jobs); err != nil {
+			return false, err
+		}
+		for _, job := range jobs {
+			s.memory.put(job)
+		}
+		return true, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for {
+		var job Job
+		err := dec.Decode(&job)
+		if err == io.EOF {
+			return false, nil
+		}
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		s.memory.put(job)
+		s.records++
+	}
+}
+
+func (s *FileStore) Get(videoID string) (Job, error) {
+	return s.memory.Get(videoID)
+}
+
+func (s *FileStore) Put(job Job) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to marshal job: %w", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return os.ErrClosed
+	}
+	if _, err = s.file.Write(append(data, '\n')); err == nil {
+		err = s.file.Sync()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write job store: %w", err)
+	}
+	_ = s.memory.Put(job)
+	s.records++
+	if s.records > compactMinRecords && s.records > 2*len(s.memory.jobs) {
+		return s.compact()
+	}
+	return nil
+}
+
+func (s *FileStore) List() ([]Job, error) {
+	return s.memory.List()
+}
+
+func (s *FileStore) Ready(now time.Time) (*Job, time.Time, error) {
+	return s.memory.Ready(now)
+}
+
+func (s *FileStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+func (s *FileStore) compact() error {
+	jobs, _ := s.memory.List()
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), "."+filepath.Base(s.path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	w := bufio.NewWriter(tmp)
+	enc := json.NewEncoder(w)
+	for _, job := range jobs {
+		if err = enc.Encode(job); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), s.path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to compact job store: %w", err)
+	}
+	if s.file != nil {
+		_ = s.file.Close()
+	}
+	if s.file, err = os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND, 0644); err != nil {
+		return fmt.Errorf("failed to reopen job store: %w", err)
+	}
+	s.records = len(jobs)
+	return nil
+}
@@ -0,0 +1,211 @@
+package crawl
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	caption "github.com/lincaiyong/youtube-caption"
+)
+
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.Advance(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.Now()
+	return ch
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+type listStore struct {
+	Store
+}
+
+func wantNext(t *testing.T, q *Queue, videoID string, wake time.Time) {
+	t.Helper()
+	job, gotWake, err := q.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := ""
+	if job != nil {
+		got = job.VideoID
+	}
+	if got != videoID || !gotWake.Equal(wake) {
+		t.Fatalf("Next() = %q, wake %v; want %q, wake %v", got, gotWake, videoID, wake)
+	}
+}
+
+func TestQueueStateTransitions(t *testing.T) {
+	for name, store := range map[string]Store{"indexed": NewMemoryStore(), "list": listStore{NewMemoryStore()}} {
+		t.Run(name, func(t *testing.T) {
+			clock := newFakeClock()
+			q := NewQueue(store)
+			q.Clock = clock
+			q.MaxAttempts = 2
+			if err := q.Add("a", "b", "c"); err != nil {
+				t.Fatal(err)
+			}
+			wantNext(t, q, "a", time.Time{})
+
+			if err := q.Fail("a", errors.New("timeout")); err != nil {
+				t.Fatal(err)
+			}
+			if err := q.Fail("b", caption.ErrNoCaptionsFound); err != nil {
+				t.Fatal(err)
+			}
+			if err := q.Done("c"); err != nil {
+				t.Fatal(err)
+			}
+			retryAt := clock.Now().Add(time.Minute)
+			wantNext(t, q, "", retryAt)
+
+			clock.Advance(time.Minute)
+			wantNext(t, q, "a", time.Time{})
+			if err := q.Fail("a", errors.New("timeout")); err != nil {
+				t.Fatal(err)
+			}
+			wantNext(t, q, "", time.Time{})
+
+			for videoID, want := range map[string]State{"a": StateFailed, "b": StateFailed, "c": StateDone} {
+				job, err := store.Get(videoID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if job.State != want {
+					t.Errorf("%s is %s, want %s", videoID, job.State, want)
+				}
+			}
+		})
+	}
+}
+
+func TestFileStoreReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.json")
+	clock := newFakeClock()
+	store, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := NewQueue(store)
+	q.Clock = clock
+	if err = q.Add("a", "b", "c"); err != nil {
+		t.Fatal(err)
+	}
+	if err = q.Done("a"); err != nil {
+		t.Fatal(err)
+	}
+	if err = q.Fail("b", caption.ErrRateLimited); err != nil {
+		t.Fatal(err)
+	}
+	if err = store.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err = OpenFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = store.Close() }()
+	q = NewQueue(store)
+	q.Clock = clock
+	wantNext(t, q, "c", time.Time{})
+	if err = q.Done("c"); err != nil {
+		t.Fatal(err)
+	}
+	retryAt := clock.Now().Add(4 * time.Minute)
+	wantNext(t, q, "", retryAt)
+	clock.Advance(4 * time.Minute)
+	wantNext(t, q, "b", time.Time{})
+
+	stats, err := q.Stats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats != (Stats{Retry: 1, Done: 2}) {
+		t.Errorf("stats = %+v", stats)
+	}
+}
+
+func TestFileStoreRecovery(t *testing.T) {
+	dir := t.TempDir()
+	legacy := filepath.Join(dir, "legacy.json")
+	if err := os.WriteFile(legacy, []byte(`[{"videoId":"a","state":"done"},{"videoId":"b","state":"pending"}]`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	torn := filepath.Join(dir, "torn.json")
+	if err := os.WriteFile(torn, []byte("{\"videoId\":\"a\",\"state\":\"done\"}\n{\"videoId\":\"b\",\"state\":\"pending\"}\n{\"videoId\":\"c\",\"sta"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{legacy, torn} {
+		store, err := OpenFileStore(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		jobs, _ := store.List()
+		if len(jobs) != 2 {
+			t.Errorf("%s: loaded %d jobs, want 2", filepath.Base(path), len(jobs))
+		}
+		if err = store.Put(Job{VideoID: "d", State: StatePending}); err != nil {
+			t.Fatal(err)
+		}
+		_ = store.Close()
+
+		store, err = OpenFileStore(path)
+		if err != nil {
+			t.Fatalf("%s: reopen after recovery: %v", filepath.Base(path), err)
+		}
+		if jobs, _ = store.List(); len(jobs) != 3 {
+			t.Errorf("%s: reloaded %d jobs, want 3", filepath.Base(path), len(jobs))
+		}
+		_ = store.Close()
+	}
+}
+
+func TestFileStoreCompacts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.json")
+	store, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = store.Close() }()
+	for i := range 3 * compactMinRecords {
+		if err = store.Put(Job{VideoID: "a", State: StateRetry, Attempts: i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := bytes.Count(data, []byte("\n")); lines > compactMinRecords+1 {
+		t.Errorf("journal has %d records for one job", lines)
+	}
+	job, err := store.Get("a")
+	if err != nil || job.Attempts != 3*compactMinRecords-1 {
+		t.Errorf("Get = %+v, %v", job, err)
+	}
+}
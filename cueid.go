@@ -0,0 +1,27 @@
+package caption
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"strconv"
+)
+
+func (s SubtitleText) ID(videoID string) string {
+	h := sha256.New()
+	h.Write([]byte(videoID))
+	h.Write([]byte{0})
+	h.Write([]byte(strconv.FormatInt(int64(math.Round(s.StartTime*1000)), 10)))
+	h.Write([]byte{0})
+	h.Write([]byte(s.Text))
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
+
+func (c *Caption) CueByID(id string) (SubtitleText, int, bool) {
+	for i, sub := range c.GetSubtitleText() {
+		if sub.ID(c.VideoID) == id {
+			return sub, i, true
+		}
+	}
+	return SubtitleText{}, -1, false
+}
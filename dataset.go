@@ -0,0 +1,146 @@
+package caption
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type DatasetOptions struct {
+	AudioPath   string
+	SpeakerID   string
+	MinDuration float64
+	MaxDuration float64
+	Lowercase   bool
+}
+
+type DatasetSegment struct {
+	ID       string  `json:"-"`
+	Offset   float64 `json:"offset"`
+	Duration float64 `json:"duration"`
+	Text     string  `json:"text"`
+}
+
+type nemoEntry struct {
+	AudioFilepath string  `json:"audio_filepath"`
+	Offset        float64 `json:"offset"`
+	Duration      float64 `json:"duration"`
+	Text          string  `json:"text"`
+}
+
+func (c *Caption) recordingID() string {
+	if c.VideoID != "" {
+		return c.VideoID
+	}
+	return "recording"
+}
+
+func (o *DatasetOptions) audioPath(c *Caption) string {
+	if o.AudioPath != "" {
+		return o.AudioPath
+	}
+	return c.recordingID() + ".wav"
+}
+
+func (c *Caption) DatasetSegments(opts *DatasetOptions) []DatasetSegment {
+	if opts == nil {
+		opts = &DatasetOptions{}
+	}
+	subs := c.GetSubtitleText()
+	var segments []DatasetSegment
+	for i, sub := range subs {
+		text := strings.Join(strings.Fields(stripSDH(sub.Text)), " ")
+		if text == "" {
+			continue
+		}
+		if opts.Lowercase {
+			text = strings.ToLower(text)
+		}
+		end := sub.EndTime
+		if end <= sub.StartTime && i+1 < len(subs) {
+			end = subs[i+1].StartTime
+		}
+		duration := math.Round((end-sub.StartTime)*1000) / 1000
+		if duration <= 0 || duration < opts.MinDuration || (opts.MaxDuration > 0 && duration > opts.MaxDuration) {
+			continue
+		}
+		startMs := int64(math.Round(sub.StartTime * 1000))
+		segments = append(segments, DatasetSegment{
+			ID:       fmt.Sprintf("%s-%08d-%08d", c.recordingID(), startMs, startMs+int64(math.Round(duration*1000))),
+			Offset:   float64(startMs) / 1000,
+			Duration: duration,
+			Text:     text,
+		})
+	}
+	return segments
+}
+
+func (c *Caption) WriteNeMoManifest(w io.Writer, opts *DatasetOptions) error {
+	if opts == nil {
+		opts = &DatasetOptions{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	for _, segment := range c.DatasetSegments(opts) {
+		entry := nemoEntry{
+			AudioFilepath: opts.audioPath(c),
+			Offset:        segment.Offset,
+			Duration:      segment.Duration,
+			Text:          segment.Text,
+		}
+		if err := enc.Encode(entry); err != nil {
+			return fmt.Errorf("failed to marshal manifest entry: %w", err)
+		}
+	}
+	return nil
+}
+
+func (c *Caption) SaveNeMoManifest(filename string, opts *DatasetOptions) error {
+	return writeFileWith(filename, nil, func(w io.Writer) error {
+		return c.WriteNeMoManifest(w, opts)
+	})
+}
+
+func (c *Caption) SaveKaldiDataset(dir string, opts *DatasetOptions) error {
+	if opts == nil {
+		opts = &DatasetOptions{}
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	recording := c.recordingID()
+	speaker := opts.SpeakerID
+	if speaker == "" {
+		speaker = recording
+	}
+
+	var segments, text, utt2spk strings.Builder
+	for _, segment := range c.DatasetSegments(opts) {
+		id := segment.ID
+		if speaker != recording {
+			id = speaker + "-" + id
+		}
+		fmt.Fprintf(&segments, "%s %s %.3f %.3f\n", id, recording, segment.Offset, segment.Offset+segment.Duration)
+		fmt.Fprintf(&text, "%s %s\n", id, segment.Text)
+		fmt.Fprintf(&utt2spk, "%s %s\n", id, speaker)
+	}
+	files := []struct {
+		name string
+		data string
+	}{
+		{"segments", segments.String()},
+		{"text", text.String()},
+		{"utt2spk", utt2spk.String()},
+		{"wav.scp", fmt.Sprintf("%s %s\n", recording, opts.audioPath(c))},
+	}
+	for _, file := range files {
+		if err := os.WriteFile(filepath.Join(dir, file.name), []byte(file.data), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file.name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package caption
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+)
+
+type DuplicateGroup struct {
+	Hash     string
+	VideoIDs []string
+}
+
+func (c *Caption) normalizedContent() string {
+	return strings.Join(quoteWords(c.GetPlainText()), " ")
+}
+
+func (c *Caption) Hash() string {
+	sum := sha256.Sum256([]byte(c.normalizedContent()))
+	return hex.EncodeToString(sum[:])
+}
+
+func FindDuplicates(results []BatchResult) []DuplicateGroup {
+	var order []string
+	groups := make(map[string][]string)
+	for _, result := range results {
+		if result.Caption == nil || result.Err != nil || result.Caption.normalizedContent() == "" {
+			continue
+		}
+		hash := result.Caption.Hash()
+		if _, ok := groups[hash]; !ok {
+			order = append(order, hash)
+		}
+		groups[hash] = append(groups[hash], result.VideoID)
+	}
+
+	var duplicates []DuplicateGroup
+	for _, hash := range order {
+		if videoIDs := groups[hash]; len(videoIDs) > 1 {
+			duplicates = append(duplicates, DuplicateGroup{Hash: hash, VideoIDs: videoIDs})
+		}
+	}
+	sort.SliceStable(duplicates, func(i, j int) bool {
+		return len(duplicates[i].VideoIDs) > len(duplicates[j].VideoIDs)
+	})
+	return duplicates
+}
@@ -0,0 +1,29 @@
+package caption
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+)
+
+func dialContext(opts *Options) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	dial := opts.DialContext
+	if dial == nil {
+		dialer := &net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+			Resolver:  opts.Resolver,
+		}
+		dial = dialer.DialContext
+	}
+	if !opts.DisableIPv6 {
+		return dial
+	}
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if strings.HasPrefix(network, "tcp") {
+			network = "tcp4"
+		}
+		return dial(ctx, network, addr)
+	}
+}
@@ -0,0 +1,127 @@
+package caption
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const defaultCacheTTL = 24 * time.Hour
+
+type cachedCaption struct {
+	Caption      *Caption      `json:"caption"`
+	Video        *VideoInfo    `json:"video,omitempty"`
+	Track        *CaptionTrack `json:"track,omitempty"`
+	Warnings     []Warning     `json:"warnings,omitempty"`
+	Corrections  []Correction  `json:"corrections,omitempty"`
+	DownloadedAt time.Time     `json:"downloadedAt"`
+}
+
+type cacheKey struct {
+	Language            string
+	Languages           []string
+	Kind                string
+	AudioTrack          string
+	Forced              ForcedMode
+	StrictLanguage      bool
+	Region              string
+	Clients             []InnerTubeClient
+	Credentials         string
+	SkipEmptyEvents     bool
+	SkipNonSpeechEvents bool
+	LowMemory           bool
+	KeepSegments        bool
+	Sanitize            bool
+	Heatmap             bool
+	MaxResponseBytes    int64
+	MaxEvents           int
+	KeepPartial         bool
+}
+
+func (o *Options) cacheTTL() time.Duration {
+	if o.CacheTTL == 0 {
+		return defaultCacheTTL
+	}
+	return o.CacheTTL
+}
+
+func cachePath(opts *Options, videoID string) string {
+	credentials := sha256.Sum256([]byte(opts.OAuthToken + "\x00" + opts.Cookies))
+	key, _ := json.Marshal(cacheKey{
+		Language:            opts.Language,
+		Languages:           opts.Languages,
+		Kind:                opts.Kind,
+		AudioTrack:          opts.AudioTrack,
+		Forced:              opts.Forced,
+		StrictLanguage:      opts.StrictLanguage,
+		Region:              opts.Region,
+		Clients:             opts.Clients,
+		Credentials:         hex.EncodeToString(credentials[:]),
+		SkipEmptyEvents:     opts.SkipEmptyEvents,
+		SkipNonSpeechEvents: opts.SkipNonSpeechEvents,
+		LowMemory:           opts.LowMemory,
+		KeepSegments:        opts.KeepSegments,
+		Sanitize:            opts.Sanitize,
+		Heatmap:             opts.Heatmap,
+		MaxResponseBytes:    opts.MaxResponseBytes,
+		MaxEvents:           opts.MaxEvents,
+		KeepPartial:         opts.KeepPartial,
+	})
+	sum := sha256.Sum256(key)
+	return filepath.Join(opts.CacheDir, videoID+"."+hex.EncodeToString(sum[:8])+".json")
+}
+
+func readCache(opts *Options, videoID string) (*Caption, bool) {
+	if opts.CacheDir == "" {
+		return nil, false
+	}
+	filename := cachePath(opts, videoID)
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, false
+	}
+	var cached cachedCaption
+	if err = json.Unmarshal(data, &cached); err != nil || cached.Caption == nil {
+		return nil, false
+	}
+	if ttl := opts.cacheTTL(); ttl > 0 && opts.clock().Now().Sub(cached.DownloadedAt) >= ttl {
+		_ = os.Remove(filename)
+		return nil, false
+	}
+	cached.Caption.size = int64(len(data))
+	cached.Caption.VideoID = videoID
+	cached.Caption.Video = cached.Video
+	cached.Caption.Track = cached.Track
+	cached.Caption.Warnings = cached.Warnings
+	cached.Caption.Corrections = cached.Corrections
+	cached.Caption.downloaded = cached.DownloadedAt
+	return cached.Caption, true
+}
+
+func writeCache(opts *Options, videoID string, caption *Caption) {
+	if opts.CacheDir == "" {
+		return
+	}
+	data, err := json.Marshal(cachedCaption{
+		Caption:      caption,
+		Video:        caption.Video,
+		Track:        caption.Track,
+		Warnings:     caption.Warnings,
+		Corrections:  caption.Corrections,
+		DownloadedAt: caption.downloaded,
+	})
+	if err != nil {
+		return
+	}
+	if err = os.MkdirAll(opts.CacheDir, 0755); err != nil {
+		return
+	}
+	_ = writeFileWith(cachePath(opts, videoID), &SaveOptions{Atomic: true}, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
@@ -0,0 +1,63 @@
+package caption
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDiskCacheKeyCoversOptions(t *testing.T) {
+	base := &Options{CacheDir: t.TempDir(), Language: "en"}
+	variants := []func(*Options){
+		func(o *Options) { o.Languages = []string{"de"} },
+		func(o *Options) { o.Region = "DE" },
+		func(o *Options) { o.AudioTrack = "de" },
+		func(o *Options) { o.Forced = ForcedExclude },
+		func(o *Options) { o.StrictLanguage = true },
+		func(o *Options) { o.SkipNonSpeechEvents = true },
+		func(o *Options) { o.Cookies = "SAPISID=x" },
+	}
+	seen := map[string]bool{cachePath(base, "vStJoetOxJg"): true}
+	for i, mutate := range variants {
+		opts := *base
+		mutate(&opts)
+		path := cachePath(&opts, "vStJoetOxJg")
+		if seen[path] {
+			t.Errorf("variant %d shares cache path %s", i, path)
+		}
+		seen[path] = true
+	}
+}
+
+func TestDiskCacheRoundTripAndTTL(t *testing.T) {
+	clock := newFakeClock()
+	opts := &Options{CacheDir: t.TempDir(), Language: "en", CacheTTL: time.Hour, Clock: clock}
+	c := (&Caption{}).withSubtitles([]SubtitleText{{StartTime: 1, EndTime: 2, Text: "hello"}})
+	c.Warnings = []Warning{{Code: WarnLanguageFallback, Message: "fell back"}}
+	c.downloaded = clock.Now()
+	writeCache(opts, "vStJoetOxJg", c)
+
+	entries, _ := os.ReadDir(opts.CacheDir)
+	for _, entry := range entries {
+		if filepath.Ext(entry.Name()) != ".json" {
+			t.Errorf("leftover temp file %s", entry.Name())
+		}
+	}
+
+	got, ok := readCache(opts, "vStJoetOxJg")
+	if !ok {
+		t.Fatal("cache miss right after write")
+	}
+	if len(got.Warnings) != 1 || got.Warnings[0].Code != WarnLanguageFallback {
+		t.Errorf("warnings = %v, want the persisted language fallback", got.Warnings)
+	}
+	if subs := got.GetSubtitleText(); len(subs) != 1 || subs[0].Text != "hello" {
+		t.Errorf("cues = %v", subs)
+	}
+
+	clock.Advance(time.Hour)
+	if _, ok := readCache(opts, "vStJoetOxJg"); ok {
+		t.Error("expired entry still served")
+	}
+}
@@ -0,0 +1,144 @@
+package caption
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	defaultEDLFrameRate = 30
+	defaultEDLReel      = "AX"
+	defaultEDLRecord    = time.Hour
+)
+
+type EDLOptions struct {
+	Title       string
+	FrameRate   int
+	Reel        string
+	Handles     time.Duration
+	RecordStart time.Duration
+}
+
+type EDLEvent struct {
+	Clip    string
+	Start   float64
+	End     float64
+	Comment string
+}
+
+func (c *Caption) EDLEvents() []EDLEvent {
+	subtitles := c.GetSubtitleText()
+	events := make([]EDLEvent, len(subtitles))
+	for i, sub := range subtitles {
+		events[i] = EDLEvent{Clip: c.VideoID, Start: sub.StartTime, End: sub.EndTime, Comment: sub.Text}
+	}
+	return events
+}
+
+func EDLEventsFromMatches(matches []SearchMatch) []EDLEvent {
+	events := make([]EDLEvent, len(matches))
+	for i, m := range matches {
+		events[i] = EDLEvent{Clip: m.VideoID, Start: m.Cue.StartTime, End: m.Cue.EndTime, Comment: m.Cue.Text}
+	}
+	return events
+}
+
+func padEDLEvents(events []EDLEvent, handles time.Duration) []EDLEvent {
+	pad := handles.Seconds()
+	var result []EDLEvent
+	for _, event := range events {
+		event.Start = max(event.Start-pad, 0)
+		event.End += pad
+		if n := len(result); n > 0 && result[n-1].Clip == event.Clip && event.Start <= result[n-1].End {
+			prev := &result[n-1]
+			prev.End = max(prev.End, event.End)
+			prev.Comment += " " + event.Comment
+			continue
+		}
+		result = append(result, event)
+	}
+	return result
+}
+
+func formatEDLTimecode(seconds float64, fps int) string {
+	frames := max(int64(math.Round(seconds*float64(fps))), 0)
+	f := int64(fps)
+	return fmt.Sprintf("%02d:%02d:%02d:%02d", frames/(f*3600), frames/(f*60)%60, frames/f%60, frames%f)
+}
+
+func WriteEDL(w io.Writer, events []EDLEvent, opts *EDLOptions) error {
+	if opts == nil {
+		opts = &EDLOptions{}
+	}
+	fps := opts.FrameRate
+	if fps <= 0 {
+		fps = defaultEDLFrameRate
+	}
+	reel := opts.Reel
+	if reel == "" {
+		reel = defaultEDLReel
+	}
+	record := defaultEDLRecord.Seconds()
+	if opts.RecordStart > 0 {
+		record = opts.RecordStart.Seconds()
+	}
+	title := opts.Title
+	if title == "" {
+		title = "Captions"
+	}
+
+	sorted := append([]EDLEvent(nil), events...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Clip != sorted[j].Clip {
+			return sorted[i].Clip < sorted[j].Clip
+		}
+		return sorted[i].Start < sorted[j].Start
+	})
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "TITLE: %s\nFCM: NON-DROP FRAME\n\n", title)
+	n := 0
+	for _, event := range padEDLEvents(sorted, opts.Handles) {
+		length := event.End - event.Start
+		if length <= 0 {
+			continue
+		}
+		n++
+		fmt.Fprintf(&b, "%03d  %-8s V     C        %s %s %s %s\n", n, reel,
+			formatEDLTimecode(event.Start, fps), formatEDLTimecode(event.End, fps),
+			formatEDLTimecode(record, fps), formatEDLTimecode(record+length, fps))
+		if event.Clip != "" {
+			fmt.Fprintf(&b, "* FROM CLIP NAME: %s\n", event.Clip)
+		}
+		if comment := strings.Join(strings.Fields(event.Comment), " "); comment != "" {
+			fmt.Fprintf(&b, "* COMMENT: %s\n", comment)
+		}
+		b.WriteString("\n")
+		record += length
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (c *Caption) GetEDL(opts *EDLOptions) string {
+	if opts == nil || opts.Title == "" {
+		withTitle := EDLOptions{}
+		if opts != nil {
+			withTitle = *opts
+		}
+		withTitle.Title = c.title()
+		opts = &withTitle
+	}
+	var b strings.Builder
+	_ = WriteEDL(&b, c.EDLEvents(), opts)
+	return b.String()
+}
+
+func (c *Caption) SaveEDL(filename string, opts *EDLOptions) error {
+	return os.WriteFile(filename, []byte(c.GetEDL(opts)), 0644)
+}
@@ -0,0 +1,122 @@
+package caption
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+)
+
+type NoCaptionsError struct {
+	VideoID  string
+	Language string
+	Tracks   []CaptionTrack
+}
+
+func (e *NoCaptionsError) Error() string {
+	langs := e.Languages()
+	if e.Language == "" || len(langs) == 0 {
+		return ErrNoCaptionsFound.Error()
+	}
+	return fmt.Sprintf("no %q captions found for this video (available: %s)", e.Language, strings.Join(langs, ", "))
+}
+
+func (e *NoCaptionsError) Is(target error) bool {
+	return target == ErrNoCaptionsFound
+}
+
+func (e *NoCaptionsError) Languages() []string {
+	var langs []string
+	seen := make(map[string]bool)
+	for _, track := range e.Tracks {
+		if track.LanguageCode != "" && !seen[track.LanguageCode] {
+			seen[track.LanguageCode] = true
+			langs = append(langs, track.LanguageCode)
+		}
+	}
+	return langs
+}
+
+var (
+	ErrPlayerRequest = errors.New("player request failed")
+	ErrTrackFetch    = errors.New("caption track fetch failed")
+	ErrParse         = errors.New("failed to parse response")
+)
+
+const snippetLimit = 512
+
+type HTTPError struct {
+	StatusCode int
+	Status     string
+	Snippet    string
+	Err        error
+}
+
+func (e *HTTPError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("HTTP %d: %v", e.StatusCode, e.Err)
+	}
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Status)
+}
+
+func (e *HTTPError) Unwrap() error {
+	return e.Err
+}
+
+func (e *HTTPError) Retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+func readSnippet(r io.Reader) string {
+	data, _ := io.ReadAll(io.LimitReader(r, snippetLimit))
+	return string(data)
+}
+
+type StageError struct {
+	Stage      error
+	StatusCode int
+	Snippet    string
+	Err        error
+}
+
+func newStageError(stage error, err error, body []byte) *StageError {
+	e := &StageError{Stage: stage, Err: err}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		e.StatusCode = httpErr.StatusCode
+		e.Snippet = httpErr.Snippet
+	}
+	if body != nil {
+		if len(body) > snippetLimit {
+			body = body[:snippetLimit]
+		}
+		e.Snippet = string(body)
+	}
+	return e
+}
+
+func (e *StageError) Error() string {
+	return fmt.Sprintf("%v: %v", e.Stage, e.Err)
+}
+
+func (e *StageError) Unwrap() []error {
+	return []error{e.Stage, e.Err}
+}
+
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Retryable()
+	}
+	if errors.Is(err, ErrRateLimited) || errors.Is(err, ErrCircuitOpen) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
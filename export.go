@@ -0,0 +1,155 @@
+package caption
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type Format string
+
+const (
+	FormatJSON     Format = "json"
+	FormatCues     Format = "cues"
+	FormatSRT      Format = "srt"
+	FormatVTT      Format = "vtt"
+	FormatText     Format = "txt"
+	FormatMarkdown Format = "md"
+	FormatHTML     Format = "html"
+	FormatTTML     Format = "ttml"
+	FormatASS      Format = "ass"
+	FormatScript   Format = "screenplay"
+	FormatChunks   Format = "chunks"
+	FormatXLIFF    Format = "xliff"
+	FormatTMX      Format = "tmx"
+	FormatBundle   Format = "zip"
+	FormatLRC      Format = "lrc"
+	FormatEDL      Format = "edl"
+	FormatChapters Format = "chapters"
+)
+
+func (f Format) Ext() string {
+	switch f {
+	case FormatCues:
+		return ".cues.json"
+	case FormatScript:
+		return ".screenplay.txt"
+	case FormatChunks:
+		return ".chunks.jsonl"
+	case FormatChapters:
+		return ".chapters.json"
+	default:
+		return "." + string(f)
+	}
+}
+
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(strings.TrimPrefix(s, "."))); f {
+	case FormatJSON, FormatCues, FormatSRT, FormatVTT, FormatText, FormatMarkdown, FormatHTML, FormatTTML, FormatASS, FormatScript, FormatChunks, FormatXLIFF, FormatTMX, FormatBundle, FormatLRC, FormatEDL, FormatChapters:
+		return f, nil
+	case "text":
+		return FormatText, nil
+	case "markdown":
+		return FormatMarkdown, nil
+	case "htm":
+		return FormatHTML, nil
+	case "dfxp":
+		return FormatTTML, nil
+	case "ssa":
+		return FormatASS, nil
+	case "script", "teleprompter":
+		return FormatScript, nil
+	case "jsonl":
+		return FormatChunks, nil
+	case "xlf":
+		return FormatXLIFF, nil
+	default:
+		return "", fmt.Errorf("unsupported format: %q", s)
+	}
+}
+
+type UploadFunc func(ctx context.Context, name string, r io.Reader) error
+
+func (c *Caption) Write(w io.Writer, format Format) error {
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(c); err != nil {
+			return fmt.Errorf("failed to marshal caption: %w", err)
+		}
+		return nil
+	case FormatCues:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(c.Export()); err != nil {
+			return fmt.Errorf("failed to marshal export: %w", err)
+		}
+		return nil
+	case FormatSRT:
+		_, err := io.WriteString(w, c.GetSRT())
+		return err
+	case FormatVTT:
+		_, err := io.WriteString(w, c.GetVTT())
+		return err
+	case FormatText:
+		_, err := io.WriteString(w, c.GetPlainText())
+		return err
+	case FormatMarkdown:
+		_, err := io.WriteString(w, c.GetMarkdown())
+		return err
+	case FormatHTML:
+		_, err := io.WriteString(w, c.GetHTML())
+		return err
+	case FormatTTML:
+		_, err := io.WriteString(w, c.GetTTML())
+		return err
+	case FormatASS:
+		_, err := io.WriteString(w, c.GetASS(nil))
+		return err
+	case FormatScript:
+		_, err := io.WriteString(w, c.GetScreenplay())
+		return err
+	case FormatChunks:
+		return c.WriteChunks(w, nil)
+	case FormatXLIFF:
+		return c.WriteXLIFF(w)
+	case FormatTMX:
+		return c.WriteTMX(w)
+	case FormatBundle:
+		return c.writeBundle(w)
+	case FormatLRC:
+		_, err := io.WriteString(w, c.GetLRC())
+		return err
+	case FormatEDL:
+		_, err := io.WriteString(w, c.GetEDL(nil))
+		return err
+	case FormatChapters:
+		return c.WriteChapters(w, nil)
+	default:
+		return fmt.Errorf("unsupported format: %q", format)
+	}
+}
+
+func (c *Caption) Upload(ctx context.Context, upload UploadFunc, name string, format Format) error {
+	pr, pw := io.Pipe()
+	written := make(chan error, 1)
+	go func() {
+		err := c.Write(pw, format)
+		pw.CloseWithError(err)
+		written <- err
+	}()
+	err := upload(ctx, name, pr)
+	if err != nil {
+		_ = pr.CloseWithError(err)
+		<-written
+		return fmt.Errorf("failed to upload %s: %w", name, err)
+	}
+	_ = pr.Close()
+	if err := <-written; err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package caption
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestUpload(t *testing.T) {
+	c := (&Caption{}).withSubtitles([]SubtitleText{
+		{StartTime: 0, EndTime: 1, Text: "first"},
+		{StartTime: 1, EndTime: 2, Text: "second"},
+	})
+	var want bytes.Buffer
+	if err := c.Write(&want, FormatSRT); err != nil {
+		t.Fatal(err)
+	}
+
+	var got bytes.Buffer
+	err := c.Upload(context.Background(), func(ctx context.Context, name string, r io.Reader) error {
+		_, err := io.Copy(&got, r)
+		return err
+	}, "captions.srt", FormatSRT)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != want.String() {
+		t.Errorf("uploaded %q, want %q", got.String(), want.String())
+	}
+
+	err = c.Upload(context.Background(), func(ctx context.Context, name string, r io.Reader) error {
+		_, err := r.Read(make([]byte, 1))
+		return err
+	}, "captions.srt", FormatSRT)
+	if !errors.Is(err, io.ErrClosedPipe) {
+		t.Errorf("short upload returned %v, want io.ErrClosedPipe", err)
+	}
+
+	failed := errors.New("bucket unavailable")
+	err = c.Upload(context.Background(), func(ctx context.Context, name string, r io.Reader) error {
+		return failed
+	}, "captions.srt", FormatSRT)
+	if !errors.Is(err, failed) {
+		t.Errorf("failed upload returned %v, want %v", err, failed)
+	}
+}
@@ -0,0 +1,133 @@
+package caption
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+func eventText(event CaptionEvent) string {
+	var text strings.Builder
+	for _, seg := range event.Segments {
+		text.WriteString(seg.UTF8)
+	}
+	return strings.TrimSpace(text.String())
+}
+
+func isNonSpeech(text string) bool {
+	return text != "" && strings.TrimSpace(soundCueRegex.ReplaceAllString(text, "")) == ""
+}
+
+func (o *Options) filtersEvents() bool {
+	return o.SkipEmptyEvents || o.SkipNonSpeechEvents
+}
+
+func (o *Options) keepEvent(event CaptionEvent) bool {
+	if !o.filtersEvents() {
+		return true
+	}
+	text := eventText(event)
+	if o.SkipEmptyEvents && text == "" {
+		return false
+	}
+	if o.SkipNonSpeechEvents && isNonSpeech(text) {
+		return false
+	}
+	return true
+}
+
+func (o *Options) streamsDecode() bool {
+	return o.LowMemory || o.filtersEvents() || o.limitsResponses()
+}
+
+func compactEvent(event CaptionEvent) (CaptionEvent, bool) {
+	var text string
+	size, parts, maxOffset := 0, 0, 0
+	for _, seg := range event.Segments {
+		if seg.UTF8 == "\n" {
+			continue
+		}
+		text = seg.UTF8
+		size += len(seg.UTF8)
+		parts++
+		maxOffset = max(maxOffset, seg.TOffsetMs)
+	}
+	if parts > 1 {
+		var b strings.Builder
+		b.Grow(size)
+		for _, seg := range event.Segments {
+			if seg.UTF8 != "\n" {
+				b.WriteString(seg.UTF8)
+			}
+		}
+		text = b.String()
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return CaptionEvent{}, false
+	}
+	compact := CaptionEvent{TStartMs: event.TStartMs, Segments: []CaptionSegment{{UTF8: text}}}
+	if maxOffset > 0 {
+		compact.Segments = append(compact.Segments, CaptionSegment{TOffsetMs: maxOffset})
+	}
+	return compact, true
+}
+
+type countingReader struct {
+	r   io.Reader
+	n   int64
+	max int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	if cr.max > 0 {
+		if cr.n > cr.max {
+			return 0, errByteLimit
+		}
+		if remaining := cr.max - cr.n + 1; int64(len(p)) > remaining {
+			p = p[:remaining]
+		}
+	}
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	if cr.max > 0 && cr.n > cr.max {
+		return n - 1, errByteLimit
+	}
+	return n, err
+}
+
+func (o *Options) decodeCaption(r io.Reader) (*Caption, error) {
+	var caption Caption
+	counter := &countingReader{r: r, max: o.MaxResponseBytes}
+	r = counter
+	compact := o.LowMemory && !o.KeepSegments
+	skipped := 0
+	err := decodeEvents(r, func(event CaptionEvent, pens []CaptionPen) error {
+		if !o.keepEvent(event) {
+			skipped++
+			return nil
+		}
+		if o.MaxEvents > 0 && len(caption.Events) >= o.MaxEvents {
+			return errEventLimit
+		}
+		if compact {
+			var ok bool
+			if event, ok = compactEvent(event); !ok {
+				return nil
+			}
+		} else {
+			caption.Pens = pens
+		}
+		caption.Events = append(caption.Events, event)
+		return nil
+	})
+	if err != nil {
+		if limitErr := o.limitError(err, counter.n, len(caption.Events), &caption); limitErr != nil {
+			return nil, newStageError(ErrTrackFetch, limitErr, nil)
+		}
+		return nil, newStageError(ErrParse, fmt.Errorf("failed to decode subtitle response: %w", err), nil)
+	}
+	caption.size = counter.n
+	caption.Warnings = skippedWarning(skipped)
+	return &caption, nil
+}
@@ -0,0 +1,82 @@
+package caption
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"runtime"
+	"testing"
+)
+
+func decodeFull(data []byte) (*Caption, error) {
+	body, err := io.ReadAll(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	var c Caption
+	if err = json.Unmarshal(body, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func decodeLowMemory(data []byte) (*Caption, error) {
+	return (&Options{LowMemory: true}).decodeCaption(bytes.NewReader(data))
+}
+
+func retainedHeap(t *testing.T, data []byte, decode func([]byte) (*Caption, error)) uint64 {
+	t.Helper()
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	c, err := decode(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	runtime.KeepAlive(c)
+	return after.HeapAlloc - min(before.HeapAlloc, after.HeapAlloc)
+}
+
+func TestLowMemoryReducesRetainedHeap(t *testing.T) {
+	data := loadFixture(t, "asr-1h")
+	full := retainedHeap(t, data, decodeFull)
+	low := retainedHeap(t, data, decodeLowMemory)
+	t.Logf("retained heap for %d bytes of json3: full %d bytes, low-memory %d bytes", len(data), full, low)
+	if low*2 > full {
+		t.Errorf("low-memory decode retained %d bytes, want at most half of %d", low, full)
+	}
+}
+
+func TestCompactEvent(t *testing.T) {
+	event := CaptionEvent{TStartMs: 1000, Segments: []CaptionSegment{
+		{UTF8: "hello", AcAsrConf: 200}, {UTF8: " world", TOffsetMs: 400, AcAsrConf: 180}, {UTF8: "\n"},
+	}}
+	got, ok := compactEvent(event)
+	want := CaptionEvent{TStartMs: 1000, Segments: []CaptionSegment{{UTF8: "hello world"}, {TOffsetMs: 400}}}
+	if !ok || len(got.Segments) != 2 || got.Segments[0] != want.Segments[0] || got.Segments[1] != want.Segments[1] {
+		t.Errorf("compactEvent() = %+v, %v; want %+v", got, ok, want)
+	}
+	if _, ok := compactEvent(CaptionEvent{Segments: []CaptionSegment{{UTF8: "\n"}, {UTF8: "  "}}}); ok {
+		t.Error("compactEvent kept a blank event")
+	}
+}
+
+func BenchmarkDecode(b *testing.B) {
+	data := loadFixture(b, "asr-1h")
+	for _, mode := range []struct {
+		name   string
+		decode func([]byte) (*Caption, error)
+	}{{"full", decodeFull}, {"lowmemory", decodeLowMemory}} {
+		b.Run(mode.name, func(b *testing.B) {
+			b.SetBytes(int64(len(data)))
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := mode.decode(data); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package caption
+
+import (
+	"sort"
+	"strings"
+)
+
+type ForcedMode int
+
+const (
+	ForcedAllow ForcedMode = iota
+	ForcedPrefer
+	ForcedExclude
+)
+
+func (ct *CaptionTrack) IsForced() bool {
+	if strings.EqualFold(ct.Kind, "forced") {
+		return true
+	}
+	vssID := strings.ToLower(ct.VssID)
+	if strings.Contains(vssID, ".forced") || strings.HasPrefix(vssID, "f.") {
+		return true
+	}
+	return strings.Contains(strings.ToLower(ct.Name.SimpleText), "forced")
+}
+
+func applyForcedMode(tracks []CaptionTrack, mode ForcedMode) []CaptionTrack {
+	result := make([]CaptionTrack, 0, len(tracks))
+	for _, track := range tracks {
+		if mode == ForcedExclude && track.IsForced() {
+			continue
+		}
+		result = append(result, track)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		fi, fj := result[i].IsForced(), result[j].IsForced()
+		if mode == ForcedPrefer {
+			return fi && !fj
+		}
+		return !fi && fj
+	})
+	return result
+}
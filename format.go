@@ -0,0 +1,222 @@
+package caption
+
+import (
+	"html"
+	"strings"
+)
+
+type TextStyle uint8
+
+const (
+	StyleBold TextStyle = 1 << iota
+	StyleItalic
+)
+
+const (
+	rubyBase   = 2
+	rubyParen  = 3
+	rubyBefore = 4
+	rubyAfter  = 5
+)
+
+type CaptionPen struct {
+	Bold      int `json:"bAttr,omitempty"`
+	Italic    int `json:"iAttr,omitempty"`
+	Underline int `json:"uAttr,omitempty"`
+	Ruby      int `json:"rbAttr,omitempty"`
+}
+
+type StyledSpan struct {
+	Text  string    `json:"text"`
+	Style TextStyle `json:"style,omitempty"`
+	Ruby  string    `json:"ruby,omitempty"`
+}
+
+const (
+	rubyBasePenOffset = 4
+	rubyTextPen       = 8
+)
+
+var formattingPens = []CaptionPen{
+	{}, {Bold: 1}, {Italic: 1}, {Bold: 1, Italic: 1},
+	{Ruby: rubyBase}, {Bold: 1, Ruby: rubyBase}, {Italic: 1, Ruby: rubyBase}, {Bold: 1, Italic: 1, Ruby: rubyBase},
+	{Ruby: rubyAfter},
+}
+
+func (p CaptionPen) style() TextStyle {
+	var style TextStyle
+	if p.Bold != 0 {
+		style |= StyleBold
+	}
+	if p.Italic != 0 {
+		style |= StyleItalic
+	}
+	return style
+}
+
+func penFor(pens []CaptionPen, id int) CaptionPen {
+	if id <= 0 || id >= len(pens) {
+		return CaptionPen{}
+	}
+	return pens[id]
+}
+
+func appendSpan(spans []StyledSpan, text string, style TextStyle) []StyledSpan {
+	if text == "" {
+		return spans
+	}
+	if n := len(spans); n > 0 && spans[n-1].Style == style && spans[n-1].Ruby == "" {
+		spans[n-1].Text += text
+		return spans
+	}
+	return append(spans, StyledSpan{Text: text, Style: style})
+}
+
+func trimSpans(spans []StyledSpan) []StyledSpan {
+	for len(spans) > 0 {
+		spans[0].Text = strings.TrimLeft(spans[0].Text, " \t\r\n")
+		if spans[0].Text != "" {
+			break
+		}
+		spans = spans[1:]
+	}
+	for len(spans) > 0 {
+		n := len(spans) - 1
+		spans[n].Text = strings.TrimRight(spans[n].Text, " \t\r\n")
+		if spans[n].Text != "" {
+			break
+		}
+		spans = spans[:n]
+	}
+	for _, span := range spans {
+		if span.Style != 0 || span.Ruby != "" {
+			return spans
+		}
+	}
+	return nil
+}
+
+func ignoredTag(tag string) bool {
+	tag = strings.TrimPrefix(tag, "/")
+	if tag != "" && tag[0] >= '0' && tag[0] <= '9' {
+		return true
+	}
+	name, _, _ := strings.Cut(tag, " ")
+	name, _, _ = strings.Cut(name, ".")
+	switch strings.ToLower(name) {
+	case "v", "c", "u", "lang", "font", "span":
+		return true
+	}
+	return false
+}
+
+func parseMarkup(markup string) (string, []StyledSpan) {
+	var spans []StyledSpan
+	var style TextStyle
+	var plain strings.Builder
+	var inRuby, inRubyText bool
+	var base, annotation strings.Builder
+	write := func(text string) {
+		switch {
+		case inRubyText:
+			annotation.WriteString(text)
+		case inRuby:
+			base.WriteString(text)
+		default:
+			spans = appendSpan(spans, text, style)
+			plain.WriteString(text)
+		}
+	}
+	for markup != "" {
+		i := strings.IndexByte(markup, '<')
+		if i < 0 {
+			write(markup)
+			break
+		}
+		write(markup[:i])
+		markup = markup[i:]
+
+		end := strings.IndexByte(markup, '>')
+		if end < 0 {
+			write(markup)
+			break
+		}
+		switch strings.ToLower(markup[:end+1]) {
+		case "<b>":
+			style |= StyleBold
+		case "</b>":
+			style &^= StyleBold
+		case "<i>":
+			style |= StyleItalic
+		case "</i>":
+			style &^= StyleItalic
+		case "<ruby>":
+			inRuby = true
+		case "<rt>":
+			inRubyText = inRuby
+		case "</rt>":
+			inRubyText = false
+		case "</ruby>":
+			if inRuby && base.Len() > 0 {
+				spans = append(spans, StyledSpan{Text: base.String(), Style: style, Ruby: annotation.String()})
+				plain.WriteString(base.String())
+			}
+			inRuby, inRubyText = false, false
+			base.Reset()
+			annotation.Reset()
+		default:
+			if !ignoredTag(markup[1:end]) {
+				write(markup[:end+1])
+			}
+		}
+		markup = markup[end+1:]
+	}
+	return strings.TrimSpace(plain.String()), trimSpans(spans)
+}
+
+func renderSpans(spans []StyledSpan, escape func(string) string, ruby bool) string {
+	var result strings.Builder
+	for _, span := range spans {
+		text := escape(span.Text)
+		if ruby && span.Ruby != "" {
+			text = "<ruby>" + text + "<rt>" + escape(span.Ruby) + "</rt></ruby>"
+		}
+		if span.Style&StyleItalic != 0 {
+			text = "<i>" + text + "</i>"
+		}
+		if span.Style&StyleBold != 0 {
+			text = "<b>" + text + "</b>"
+		}
+		result.WriteString(text)
+	}
+	return result.String()
+}
+
+func (s SubtitleText) Markup() string {
+	if len(s.Spans) == 0 {
+		return s.Text
+	}
+	return renderSpans(s.Spans, func(text string) string { return text }, false)
+}
+
+func (s SubtitleText) vttMarkup() string {
+	if len(s.Spans) == 0 {
+		return s.Text
+	}
+	return renderSpans(s.Spans, func(text string) string { return text }, true)
+}
+
+func (s SubtitleText) html() string {
+	if len(s.Spans) == 0 {
+		return html.EscapeString(s.Text)
+	}
+	return renderSpans(s.Spans, html.EscapeString, true)
+}
+
+func (c *Caption) StripFormatting() *Caption {
+	subs := c.GetSubtitleText()
+	for i := range subs {
+		subs[i].Spans = nil
+	}
+	return c.withSubtitles(subs)
+}
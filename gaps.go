@@ -0,0 +1,59 @@
+package caption
+
+import (
+	"math"
+	"time"
+)
+
+type Gap struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+func (g Gap) Duration() time.Duration {
+	return g.End - g.Start
+}
+
+func (c *Caption) Gaps(min time.Duration) []Gap {
+	var gaps []Gap
+	var covered float64
+	add := func(start, end float64) {
+		gap := Gap{Start: secondsToDuration(start), End: secondsToDuration(end)}
+		if gap.Duration() > 0 && gap.Duration() >= min {
+			gaps = append(gaps, gap)
+		}
+	}
+
+	for _, sub := range c.GetSubtitleText() {
+		if sub.StartTime > covered {
+			add(covered, sub.StartTime)
+		}
+		covered = math.Max(covered, sub.EndTime)
+	}
+	if c.Video != nil && float64(c.Video.LengthSeconds) > covered {
+		add(covered, float64(c.Video.LengthSeconds))
+	}
+	return gaps
+}
+
+func (c *Caption) Coverage() float64 {
+	var total float64
+	if c.Video != nil {
+		total = float64(c.Video.LengthSeconds)
+	}
+	var covered, end float64
+	for _, sub := range c.GetSubtitleText() {
+		start := math.Max(sub.StartTime, end)
+		if sub.EndTime > start {
+			covered += sub.EndTime - start
+		}
+		end = math.Max(end, sub.EndTime)
+	}
+	if total < end {
+		total = end
+	}
+	if total == 0 {
+		return 0
+	}
+	return covered / total
+}
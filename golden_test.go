@@ -0,0 +1,16 @@
+package caption_test
+
+import (
+	"testing"
+
+	caption "github.com/lincaiyong/youtube-caption"
+	"github.com/lincaiyong/youtube-caption/testutil"
+)
+
+func TestGoldenFormats(t *testing.T) {
+	for _, format := range []caption.Format{caption.FormatSRT, caption.FormatVTT, caption.FormatASS, caption.FormatTTML} {
+		t.Run(string(format), func(t *testing.T) {
+			testutil.AssertFormat(t, testutil.SampleCaption(), format)
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package caption
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const nextURL = "https://www.youtube.com/youtubei/v1/next?prettyPrint=false"
+
+type HeatMarker struct {
+	Start     time.Duration `json:"start"`
+	Duration  time.Duration `json:"duration"`
+	Intensity float64       `json:"intensity"`
+}
+
+type Highlight struct {
+	Start     time.Duration
+	End       time.Duration
+	Intensity float64
+	Cues      []SubtitleText
+	Text      string
+}
+
+func jsonNumber(v any) float64 {
+	switch n := v.(type) {
+	case float64:
+		return n
+	case string:
+		f, _ := strconv.ParseFloat(n, 64)
+		return f
+	}
+	return 0
+}
+
+func findHeatMarkers(node any, markers []HeatMarker) []HeatMarker {
+	switch v := node.(type) {
+	case map[string]any:
+		if r, ok := v["heatMarkerRenderer"].(map[string]any); ok {
+			return append(markers, HeatMarker{
+				Start:     time.Duration(jsonNumber(r["timeRangeStartMillis"])) * time.Millisecond,
+				Duration:  time.Duration(jsonNumber(r["markerDurationMillis"])) * time.Millisecond,
+				Intensity: jsonNumber(r["heatMarkerIntensityScoreNormalized"]),
+			})
+		}
+		if list, ok := v["markersList"].(map[string]any); ok && strings.Contains(fmt.Sprint(list["markerType"]), "HEATMAP") {
+			items, _ := list["markers"].([]any)
+			for _, item := range items {
+				m, _ := item.(map[string]any)
+				markers = append(markers, HeatMarker{
+					Start:     time.Duration(jsonNumber(m["startMillis"])) * time.Millisecond,
+					Duration:  time.Duration(jsonNumber(m["durationMillis"])) * time.Millisecond,
+					Intensity: jsonNumber(m["intensityScoreNormalized"]),
+				})
+			}
+			return markers
+		}
+		for _, child := range v {
+			markers = findHeatMarkers(child, markers)
+		}
+	case []any:
+		for _, child := range v {
+			markers = findHeatMarkers(child, markers)
+		}
+	}
+	return markers
+}
+
+func GetHeatmap(ctx context.Context, videoID string, opts *Options) ([]HeatMarker, error) {
+	return NewClient(opts).GetHeatmap(ctx, videoID)
+}
+
+func (c *Client) GetHeatmap(ctx context.Context, videoID string) ([]HeatMarker, error) {
+	if err := validateVideoID(videoID); err != nil {
+		return nil, err
+	}
+	client := c.clientVersion(ctx, c.opts.innerTubeClients()[0])
+	data, err := makeRequestData(videoID, client, c.opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request data: %w", err)
+	}
+	req, err := http.NewRequest("POST", nextURL, bytes.NewBuffer(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", c.opts.userAgentFor(client))
+
+	resp, err := c.do(ctx, req)
+	if err != nil {
+		return nil, newStageError(ErrPlayerRequest, err, nil)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, newStageError(ErrPlayerRequest, fmt.Errorf("failed to read response: %w", err), nil)
+	}
+	var root any
+	if err = json.Unmarshal(body, &root); err != nil {
+		return nil, newStageError(ErrParse, fmt.Errorf("failed to unmarshal response: %w", err), body)
+	}
+	markers := findHeatMarkers(root, nil)
+	sort.Slice(markers, func(i, j int) bool {
+		return markers[i].Start < markers[j].Start
+	})
+	return markers, nil
+}
+
+func (c *Caption) HighlightsByHeatmap(n int) []Highlight {
+	if c.Video == nil {
+		return nil
+	}
+	return c.Highlights(c.Video.Heatmap, n)
+}
+
+func (c *Caption) Highlights(markers []HeatMarker, n int) []Highlight {
+	ranked := make([]HeatMarker, len(markers))
+	copy(ranked, markers)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Intensity > ranked[j].Intensity
+	})
+
+	var chosen []HeatMarker
+	for _, m := range ranked {
+		if len(chosen) >= n {
+			break
+		}
+		adjacent := false
+		for _, other := range chosen {
+			if m.Start <= other.Start+other.Duration && other.Start <= m.Start+m.Duration {
+				adjacent = true
+				break
+			}
+		}
+		if !adjacent {
+			chosen = append(chosen, m)
+		}
+	}
+	sort.Slice(chosen, func(i, j int) bool {
+		return chosen[i].Start < chosen[j].Start
+	})
+
+	subtitles := c.GetSubtitleText()
+	highlights := make([]Highlight, 0, len(chosen))
+	for _, m := range chosen {
+		h := Highlight{Start: m.Start, End: m.Start + m.Duration, Intensity: m.Intensity}
+		var text []string
+		for _, sub := range subtitles {
+			if secondsToDuration(sub.EndTime) > h.Start && secondsToDuration(sub.StartTime) < h.End {
+				h.Cues = append(h.Cues, sub)
+				text = append(text, strings.ReplaceAll(sub.Text, "\n", " "))
+			}
+		}
+		h.Text = strings.Join(text, " ")
+		highlights = append(highlights, h)
+	}
+	return highlights
+}
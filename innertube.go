@@ -0,0 +1,75 @@
+package caption
+
+import (
+	"context"
+	"errors"
+)
+
+type InnerTubeClient struct {
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	UserAgent string `json:"userAgent,omitempty"`
+}
+
+const (
+	ClientNameWeb        = "WEB"
+	ClientNameAndroid    = "ANDROID"
+	ClientNameIOS        = "IOS"
+	ClientNameTVEmbedded = "TVHTML5_SIMPLY_EMBEDDED_PLAYER"
+)
+
+var (
+	WebClient = InnerTubeClient{
+		Name:    ClientNameWeb,
+		Version: "2.20250925.01.00",
+	}
+	AndroidClient = InnerTubeClient{
+		Name:      ClientNameAndroid,
+		Version:   "20.10.38",
+		UserAgent: "com.google.android.youtube/20.10.38 (Linux; U; Android 14) gzip",
+	}
+	IOSClient = InnerTubeClient{
+		Name:      ClientNameIOS,
+		Version:   "20.10.4",
+		UserAgent: "com.google.ios.youtube/20.10.4 (iPhone16,2; U; CPU iOS 18_3_2 like Mac OS X;)",
+	}
+	TVEmbeddedClient = InnerTubeClient{
+		Name:    ClientNameTVEmbedded,
+		Version: "2.0",
+	}
+)
+
+func DefaultInnerTubeClients() []InnerTubeClient {
+	return []InnerTubeClient{WebClient}
+}
+
+func (o *Options) innerTubeClients() []InnerTubeClient {
+	var clients []InnerTubeClient
+	for _, client := range o.Clients {
+		if client.Name != "" && client.Version != "" {
+			clients = append(clients, client)
+		}
+	}
+	if len(clients) == 0 {
+		return DefaultInnerTubeClients()
+	}
+	return clients
+}
+
+func (o *Options) userAgentFor(client InnerTubeClient) string {
+	if client.UserAgent != "" {
+		return client.UserAgent
+	}
+	return o.UserAgent
+}
+
+func (p *playerResponse) needsFallback() bool {
+	if len(p.Captions.PlayerCaptionsTracklistRenderer.CaptionTracks) > 0 {
+		return false
+	}
+	return p.PlayabilityStatus.Status != "" && p.PlayabilityStatus.Status != "OK" && !p.upcoming()
+}
+
+func retryWithNextClient(err error) bool {
+	return !errors.Is(err, ErrRateLimited) && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
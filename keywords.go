@@ -0,0 +1,125 @@
+package caption
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+type Keyword struct {
+	Word  string  `json:"word"`
+	Score float64 `json:"score"`
+	Count int     `json:"count"`
+	Start float64 `json:"start"`
+}
+
+type Background interface {
+	IDF(word string) float64
+}
+
+type RankedBackground struct {
+	ranks map[string]int
+}
+
+func NewRankedBackground(words []string) *RankedBackground {
+	ranks := make(map[string]int, len(words))
+	for _, word := range words {
+		if norm := normalizeWord(word); norm != "" {
+			if _, ok := ranks[norm]; !ok {
+				ranks[norm] = len(ranks)
+			}
+		}
+	}
+	return &RankedBackground{ranks: ranks}
+}
+
+func (b *RankedBackground) IDF(word string) float64 {
+	if rank, ok := b.ranks[word]; ok {
+		return math.Log(float64(rank + 2))
+	}
+	return math.Log(float64(len(b.ranks)+2)) + 1
+}
+
+type DocumentFrequencies struct {
+	docs int
+	df   map[string]int
+}
+
+func NewDocumentFrequencies(captions []*Caption) *DocumentFrequencies {
+	d := &DocumentFrequencies{df: make(map[string]int)}
+	for _, c := range captions {
+		d.Add(c)
+	}
+	return d
+}
+
+func (d *DocumentFrequencies) Add(c *Caption) {
+	seen := make(map[string]bool)
+	for _, sub := range c.GetSubtitleText() {
+		for _, word := range strings.Fields(sub.Text) {
+			if norm := normalizeWord(word); norm != "" && !seen[norm] {
+				seen[norm] = true
+				d.df[norm]++
+			}
+		}
+	}
+	d.docs++
+}
+
+func (d *DocumentFrequencies) IDF(word string) float64 {
+	return math.Log(float64(d.docs+1)/float64(d.df[word]+1)) + 1
+}
+
+var DefaultBackground Background = NewRankedBackground(strings.Fields(`people time way year day thing man
+	woman life world work make say go take come think look want give use find tell ask seem feel try leave call
+	good new first last long great little old big high different small large next early young important bad
+	sure mean need start show put keep talk something everything anything nothing always never maybe pretty
+	much many even still back today said says thank thanks video guys welcome channel subscribe child school
+	state family student group country problem hand part place case week company system program question
+	government number night point home water room mother area money story fact month study book eye job word
+	business issue side head house service friend father power hour game line end member law car city name
+	team minute idea kid body information parent face others level office door health person art war history
+	party result change morning reason research girl guy moment air teacher force education basically stuff
+	bit whole able probably sort course quite done made came went told gave took getting doing saying trying
+	looking talking working coming makes takes goes comes wanted means happen happened different another
+	every around maybe everyone someone anyone real true little better best least less enough`))
+
+func (c *Caption) Keywords(n int) []Keyword {
+	return c.KeywordsWith(n, DefaultBackground)
+}
+
+func (c *Caption) KeywordsWith(n int, background Background) []Keyword {
+	if n <= 0 {
+		return nil
+	}
+	if background == nil {
+		background = DefaultBackground
+	}
+	counts := make(map[string]*Keyword)
+	for _, sub := range c.GetSubtitleText() {
+		for _, word := range strings.Fields(sub.Text) {
+			norm := normalizeWord(word)
+			if norm == "" || stopWords[norm] || len([]rune(norm)) <= 2 {
+				continue
+			}
+			if kw, ok := counts[norm]; ok {
+				kw.Count++
+				continue
+			}
+			counts[norm] = &Keyword{Word: norm, Count: 1, Start: sub.StartTime}
+		}
+	}
+
+	keywords := make([]Keyword, 0, len(counts))
+	for word, kw := range counts {
+		kw.Score = float64(kw.Count) * background.IDF(word)
+		keywords = append(keywords, *kw)
+	}
+	sort.Slice(keywords, func(i, j int) bool {
+		if keywords[i].Score != keywords[j].Score {
+			return keywords[i].Score > keywords[j].Score
+		}
+		return keywords[i].Word < keywords[j].Word
+	})
+	return keywords[:min(n, len(keywords))]
+}
@@ -0,0 +1,64 @@
+package caption
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+var ErrLimitExceeded = errors.New("response limit exceeded")
+
+var (
+	errByteLimit  = errors.New("byte limit reached")
+	errEventLimit = errors.New("event limit reached")
+)
+
+type LimitExceededError struct {
+	Limit    string
+	Max      int64
+	Received int64
+	Partial  *Caption
+}
+
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("%v: more than %d %s", ErrLimitExceeded, e.Max, e.Limit)
+}
+
+func (e *LimitExceededError) Is(target error) bool {
+	return target == ErrLimitExceeded
+}
+
+func (o *Options) limitsResponses() bool {
+	return o.MaxResponseBytes > 0 || o.MaxEvents > 0
+}
+
+func (o *Options) limitError(err error, bytes int64, events int, partial *Caption) *LimitExceededError {
+	var limitErr *LimitExceededError
+	switch {
+	case errors.Is(err, errByteLimit):
+		limitErr = &LimitExceededError{Limit: "bytes", Max: o.MaxResponseBytes, Received: bytes}
+	case errors.Is(err, errEventLimit):
+		limitErr = &LimitExceededError{Limit: "events", Max: int64(o.MaxEvents), Received: int64(events)}
+	default:
+		return nil
+	}
+	if o.KeepPartial && partial != nil {
+		partial.size = bytes
+		limitErr.Partial = partial
+	}
+	return limitErr
+}
+
+func (o *Options) readLimited(r io.Reader) ([]byte, error) {
+	if o.MaxResponseBytes <= 0 {
+		return io.ReadAll(r)
+	}
+	data, err := io.ReadAll(io.LimitReader(r, o.MaxResponseBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > o.MaxResponseBytes {
+		return nil, &LimitExceededError{Limit: "bytes", Max: o.MaxResponseBytes, Received: int64(len(data))}
+	}
+	return data, nil
+}
@@ -0,0 +1,86 @@
+package caption
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+type LinkedCue struct {
+	SubtitleText
+	URL string `json:"url"`
+}
+
+func (s SubtitleText) URL(videoID string) string {
+	return fmt.Sprintf("https://youtu.be/%s?t=%d", videoID, int(s.StartTime))
+}
+
+func (c *Caption) WithLinks(videoID string) []LinkedCue {
+	if videoID == "" {
+		videoID = c.VideoID
+	}
+	subtitles := c.GetSubtitleText()
+	result := make([]LinkedCue, len(subtitles))
+	for i, sub := range subtitles {
+		result[i] = LinkedCue{SubtitleText: sub, URL: sub.URL(videoID)}
+	}
+	return result
+}
+
+func (c *Caption) title() string {
+	if c.Video != nil && c.Video.Title != "" {
+		return c.Video.Title
+	}
+	if c.VideoID != "" {
+		return c.VideoID
+	}
+	return "Transcript"
+}
+
+func (c *Caption) GetMarkdown() string {
+	return c.GetMarkdownWithOptions(nil)
+}
+
+func (c *Caption) GetMarkdownWithOptions(opts *PageOptions) string {
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("# %s\n\n", c.title()))
+	if opts != nil && opts.Thumbnail {
+		if url := c.thumbnailURL(); url != "" {
+			result.WriteString(fmt.Sprintf("![%s](%s)\n\n", c.title(), url))
+		}
+	}
+	for _, cue := range c.WithLinks(c.VideoID) {
+		stamp := formatClock(cue.StartTime)
+		if c.VideoID != "" {
+			result.WriteString(fmt.Sprintf("[%s](%s) %s\n\n", stamp, cue.URL, cue.Text))
+		} else {
+			result.WriteString(fmt.Sprintf("%s %s\n\n", stamp, cue.Text))
+		}
+	}
+	return result.String()
+}
+
+func (c *Caption) GetHTML() string {
+	return c.GetHTMLWithOptions(nil)
+}
+
+func (c *Caption) GetHTMLWithOptions(opts *PageOptions) string {
+	var result strings.Builder
+	title := html.EscapeString(c.title())
+	result.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	result.WriteString(fmt.Sprintf("<title>%s</title>\n</head>\n<body>\n<h1>%s</h1>\n", title, title))
+	if opts != nil && opts.Thumbnail {
+		if url := c.thumbnailURL(); url != "" {
+			result.WriteString(fmt.Sprintf("<img src=\"%s\" alt=\"%s\">\n", html.EscapeString(url), title))
+		}
+	}
+	for _, cue := range c.WithLinks(c.VideoID) {
+		stamp := formatClock(cue.StartTime)
+		if c.VideoID != "" {
+			stamp = fmt.Sprintf("<a href=\"%s\">%s</a>", html.EscapeString(cue.URL), stamp)
+		}
+		result.WriteString(fmt.Sprintf("<p>%s %s</p>\n", stamp, cue.html()))
+	}
+	result.WriteString("</body>\n</html>\n")
+	return result.String()
+}
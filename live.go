@@ -0,0 +1,64 @@
+package caption
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+var ErrNotYetAvailable = errors.New("video has not premiered yet")
+
+type NotYetAvailableError struct {
+	VideoID        string
+	ScheduledStart time.Time
+}
+
+func (e *NotYetAvailableError) Error() string {
+	if e.ScheduledStart.IsZero() {
+		return ErrNotYetAvailable.Error()
+	}
+	return fmt.Sprintf("%v (scheduled for %s)", ErrNotYetAvailable, e.ScheduledStart.UTC().Format(time.RFC3339))
+}
+
+func (e *NotYetAvailableError) Is(target error) bool {
+	return target == ErrNotYetAvailable
+}
+
+type playabilityStatus struct {
+	Status            string                     `json:"status"`
+	Reason            string                     `json:"reason"`
+	ErrorScreen       map[string]json.RawMessage `json:"errorScreen"`
+	LiveStreamability *struct {
+		LiveStreamabilityRenderer struct {
+			OfflineSlate struct {
+				LiveStreamOfflineSlateRenderer struct {
+					ScheduledStartTime string `json:"scheduledStartTime"`
+				} `json:"liveStreamOfflineSlateRenderer"`
+			} `json:"offlineSlate"`
+		} `json:"liveStreamabilityRenderer"`
+	} `json:"liveStreamability"`
+}
+
+func (p *playerResponse) scheduledStart() time.Time {
+	if ls := p.PlayabilityStatus.LiveStreamability; ls != nil {
+		slate := ls.LiveStreamabilityRenderer.OfflineSlate.LiveStreamOfflineSlateRenderer
+		if sec, err := strconv.ParseInt(slate.ScheduledStartTime, 10, 64); err == nil && sec > 0 {
+			return time.Unix(sec, 0)
+		}
+	}
+	if details := p.Microformat.PlayerMicroformatRenderer.LiveBroadcastDetails; details != nil {
+		if t, err := time.Parse(time.RFC3339, details.StartTimestamp); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (p *playerResponse) upcoming() bool {
+	if p.VideoDetails.IsUpcoming {
+		return true
+	}
+	return p.PlayabilityStatus.Status == "LIVE_STREAM_OFFLINE" && !p.scheduledStart().IsZero()
+}
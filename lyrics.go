@@ -0,0 +1,91 @@
+package caption
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+const lyricMarkers = "♪♫♬♩"
+
+func (s SubtitleText) IsLyricLine() bool {
+	return strings.ContainsAny(s.Text, lyricMarkers) && stripLyricMarkers(s.Text) != ""
+}
+
+func stripLyricMarkers(text string) string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(strings.Map(func(r rune) rune {
+			if strings.ContainsRune(lyricMarkers, r) {
+				return ' '
+			}
+			return r
+		}, line))
+		if line = spaceRunRegex.ReplaceAllString(line, " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (c *Caption) Lyrics() *Caption {
+	var result []SubtitleText
+	open := false
+	for _, sub := range c.GetSubtitleText() {
+		markers := 0
+		for _, r := range sub.Text {
+			if strings.ContainsRune(lyricMarkers, r) {
+				markers++
+			}
+		}
+		lyric := open || sub.IsLyricLine()
+		if markers%2 == 1 {
+			open = !open
+		}
+		if !lyric {
+			continue
+		}
+		if text := stripLyricMarkers(sub.Text); text != "" {
+			if text != sub.Text {
+				sub.Text = text
+				sub.Spans = nil
+			}
+			result = append(result, sub)
+		}
+	}
+	return c.withSubtitles(result)
+}
+
+func formatLRCTime(seconds float64) string {
+	cs := max(secondsToDuration(seconds).Milliseconds()/10, 0)
+	return fmt.Sprintf("[%02d:%02d.%02d]", cs/6000, cs/100%60, cs%100)
+}
+
+func (c *Caption) GetLRC() string {
+	var result strings.Builder
+	if c.Video != nil {
+		if c.Video.Title != "" {
+			result.WriteString("[ti:" + c.Video.Title + "]\n")
+		}
+		if c.Video.Author != "" {
+			result.WriteString("[ar:" + c.Video.Author + "]\n")
+		}
+		if c.Video.LengthSeconds > 0 {
+			result.WriteString(fmt.Sprintf("[length:%02d:%02d]\n", c.Video.LengthSeconds/60, c.Video.LengthSeconds%60))
+		}
+	}
+	subtitles := c.GetSubtitleText()
+	for i, sub := range subtitles {
+		for _, line := range strings.Split(sub.Text, "\n") {
+			result.WriteString(formatLRCTime(sub.StartTime) + line + "\n")
+		}
+		if i+1 < len(subtitles) && subtitles[i+1].StartTime-sub.EndTime >= 1 {
+			result.WriteString(formatLRCTime(sub.EndTime) + "\n")
+		}
+	}
+	return result.String()
+}
+
+func (c *Caption) SaveLRC(filename string) error {
+	return os.WriteFile(filename, []byte(c.GetLRC()), 0644)
+}
@@ -0,0 +1,160 @@
+package caption
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+)
+
+const ManifestFile = "manifest.json"
+
+const modulePath = "github.com/lincaiyong/youtube-caption"
+
+var manifestMu sync.Mutex
+
+type ManifestEntry struct {
+	File         string     `json:"file"`
+	SHA256       string     `json:"sha256"`
+	Size         int64      `json:"size"`
+	Format       Format     `json:"format"`
+	VideoID      string     `json:"videoId"`
+	Language     string     `json:"language,omitempty"`
+	Kind         string     `json:"kind,omitempty"`
+	TrackName    string     `json:"trackName,omitempty"`
+	DownloadedAt *time.Time `json:"downloadedAt,omitempty"`
+	ExportedAt   time.Time  `json:"exportedAt"`
+}
+
+type Manifest struct {
+	LibraryVersion string          `json:"libraryVersion"`
+	Files          []ManifestEntry `json:"files"`
+}
+
+func LibraryVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	if info.Main.Path == modulePath {
+		return info.Main.Version
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == modulePath {
+			if dep.Replace != nil {
+				return dep.Replace.Version
+			}
+			return dep.Version
+		}
+	}
+	return "unknown"
+}
+
+func (c *Caption) manifestEntry(filename string, format Format, sum []byte, size int64, now time.Time) ManifestEntry {
+	entry := ManifestEntry{
+		File:       filepath.Base(filename),
+		SHA256:     hex.EncodeToString(sum),
+		Size:       size,
+		Format:     format,
+		VideoID:    c.VideoID,
+		ExportedAt: now.UTC(),
+	}
+	if c.Track != nil {
+		entry.Language = c.Track.LanguageCode
+		entry.Kind = c.Track.Kind
+		entry.TrackName = c.Track.Name.SimpleText
+	}
+	if !c.downloaded.IsZero() {
+		downloaded := c.downloaded.UTC()
+		entry.DownloadedAt = &downloaded
+	}
+	return entry
+}
+
+func (c *Caption) clock() Clock {
+	if c.client != nil {
+		return c.client.opts.clock()
+	}
+	return SystemClock
+}
+
+func LoadManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err = json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	return &m, nil
+}
+
+func UpdateManifest(dir string, entry ManifestEntry) error {
+	manifestMu.Lock()
+	defer manifestMu.Unlock()
+
+	m, err := LoadManifest(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		m, err = &Manifest{}, nil
+	}
+	if err != nil {
+		return err
+	}
+	m.LibraryVersion = LibraryVersion()
+	replaced := false
+	for i := range m.Files {
+		if m.Files[i].File == entry.File {
+			m.Files[i], replaced = entry, true
+		}
+	}
+	if !replaced {
+		m.Files = append(m.Files, entry)
+	}
+	sort.Slice(m.Files, func(i, j int) bool { return m.Files[i].File < m.Files[j].File })
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	return writeFileWith(filepath.Join(dir, ManifestFile), &SaveOptions{Atomic: true}, func(w io.Writer) error {
+		_, err := w.Write(append(data, '\n'))
+		return err
+	})
+}
+
+func VerifyManifest(dir string) ([]string, error) {
+	m, err := LoadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	var mismatched []string
+	for _, entry := range m.Files {
+		sum, err := fileSHA256(filepath.Join(dir, entry.File))
+		if err != nil || sum != entry.SHA256 {
+			mismatched = append(mismatched, entry.File)
+		}
+	}
+	return mismatched, nil
+}
+
+func fileSHA256(filename string) (string, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
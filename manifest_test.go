@@ -0,0 +1,36 @@
+package caption
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestManifestExportedAtUsesClock(t *testing.T) {
+	dir := t.TempDir()
+	clientClock := newFakeClock()
+	c := (&Caption{client: NewClient(&Options{Clock: clientClock})}).withSubtitles([]SubtitleText{{StartTime: 0, EndTime: 1, Text: "hello"}})
+
+	if err := c.SaveWithOptions(filepath.Join(dir, "a.srt"), FormatSRT, &SaveOptions{Manifest: true}); err != nil {
+		t.Fatal(err)
+	}
+	saveClock := newFakeClock()
+	saveClock.Advance(time.Hour)
+	if err := c.SaveWithOptions(filepath.Join(dir, "b.srt"), FormatSRT, &SaveOptions{Manifest: true, Clock: saveClock}); err != nil {
+		t.Fatal(err)
+	}
+
+	manifest, err := LoadManifest(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]time.Time{"a.srt": clientClock.Now(), "b.srt": saveClock.Now()}
+	for _, entry := range manifest.Files {
+		if !entry.ExportedAt.Equal(want[entry.File]) {
+			t.Errorf("%s exported at %v, want %v", entry.File, entry.ExportedAt, want[entry.File])
+		}
+	}
+	if len(manifest.Files) != len(want) {
+		t.Errorf("manifest has %d entries, want %d", len(manifest.Files), len(want))
+	}
+}
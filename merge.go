@@ -0,0 +1,56 @@
+package caption
+
+import "sort"
+
+type MergedRegion struct {
+	Start float64
+	End   float64
+	Cues  int
+}
+
+func MergePreferManual(manual, asr *Caption) (*Caption, []MergedRegion) {
+	if asr == nil {
+		return manual, nil
+	}
+	if manual == nil {
+		return asr, nil
+	}
+
+	manualSubs := manual.GetSubtitleText()
+	subs := append([]SubtitleText(nil), manualSubs...)
+	var regions []MergedRegion
+	next, lastManual := 0, -1
+	for _, sub := range asr.GetSubtitleText() {
+		for next < len(manualSubs) && manualSubs[next].EndTime <= sub.StartTime {
+			next++
+		}
+		if overlapsManual(manualSubs, next, sub) {
+			continue
+		}
+		subs = append(subs, sub)
+		if n := len(regions); n > 0 && lastManual == next {
+			regions[n-1].End = max(regions[n-1].End, sub.EndTime)
+			regions[n-1].Cues++
+		} else {
+			regions = append(regions, MergedRegion{Start: sub.StartTime, End: sub.EndTime, Cues: 1})
+		}
+		lastManual = next
+	}
+
+	sort.SliceStable(subs, func(i, j int) bool {
+		return subs[i].StartTime < subs[j].StartTime
+	})
+	return manual.withSubtitles(subs), regions
+}
+
+func overlapsManual(manual []SubtitleText, from int, sub SubtitleText) bool {
+	for _, m := range manual[from:] {
+		if m.StartTime >= sub.EndTime && m.StartTime > sub.StartTime {
+			break
+		}
+		if m.EndTime > sub.StartTime {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package caption
+
+import "context"
+
+type LiveBroadcastDetails struct {
+	IsLiveNow      bool   `json:"isLiveNow"`
+	StartTimestamp string `json:"startTimestamp,omitempty"`
+	EndTimestamp   string `json:"endTimestamp,omitempty"`
+}
+
+type Microformat struct {
+	Category             string                `json:"category,omitempty"`
+	PublishDate          string                `json:"publishDate,omitempty"`
+	UploadDate           string                `json:"uploadDate,omitempty"`
+	IsFamilySafe         bool                  `json:"isFamilySafe"`
+	IsUnlisted           bool                  `json:"isUnlisted,omitempty"`
+	AvailableCountries   []string              `json:"availableCountries,omitempty"`
+	OwnerProfileURL      string                `json:"ownerProfileUrl,omitempty"`
+	OwnerChannelName     string                `json:"ownerChannelName,omitempty"`
+	ExternalChannelID    string                `json:"externalChannelId,omitempty"`
+	LiveBroadcastDetails *LiveBroadcastDetails `json:"liveBroadcastDetails,omitempty"`
+}
+
+func (p *playerResponse) videoInfo() *VideoInfo {
+	video := p.VideoDetails
+	microformat := p.Microformat.PlayerMicroformatRenderer
+	video.Microformat = &microformat
+	return &video
+}
+
+func GetVideoInfo(ctx context.Context, videoID string, opts *Options) (*VideoInfo, error) {
+	return NewClient(opts).GetVideoInfo(ctx, videoID)
+}
+
+func (c *Client) GetVideoInfo(ctx context.Context, videoID string) (*VideoInfo, error) {
+	if err := validateVideoID(videoID); err != nil {
+		return nil, err
+	}
+	playerResp, err := c.requestPlayer(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+	return playerResp.videoInfo(), nil
+}
+
+func GetMicroformat(ctx context.Context, videoID string, opts *Options) (*Microformat, error) {
+	video, err := GetVideoInfo(ctx, videoID, opts)
+	if err != nil {
+		return nil, err
+	}
+	return video.Microformat, nil
+}
@@ -0,0 +1,98 @@
+package caption
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type Notifier interface {
+	Notify(ctx context.Context, result BatchResult) error
+}
+
+type NotifierFunc func(ctx context.Context, result BatchResult) error
+
+func (f NotifierFunc) Notify(ctx context.Context, result BatchResult) error {
+	return f(ctx, result)
+}
+
+type WebhookNotifier struct {
+	URL        string
+	HTTPClient *http.Client
+	Headers    map[string]string
+}
+
+type webhookPayload struct {
+	Text      string `json:"text"`
+	Status    string `json:"status"`
+	Input     string `json:"input"`
+	VideoID   string `json:"videoId,omitempty"`
+	Error     string `json:"error,omitempty"`
+	Bytes     int64  `json:"bytes"`
+	ElapsedMs int64  `json:"elapsedMs"`
+}
+
+func resultStatus(result BatchResult) string {
+	switch {
+	case result.Skipped:
+		return "skipped"
+	case result.Err != nil:
+		return "failed"
+	default:
+		return "ok"
+	}
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, result BatchResult) error {
+	payload := webhookPayload{
+		Status:    resultStatus(result),
+		Input:     result.Input,
+		VideoID:   result.VideoID,
+		Bytes:     result.Bytes,
+		ElapsedMs: result.Elapsed.Milliseconds(),
+	}
+	payload.Text = fmt.Sprintf("%s: %s", result.Input, payload.Status)
+	if result.Err != nil {
+		payload.Error = result.Err.Error()
+		payload.Text += " (" + payload.Error + ")"
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", n.URL, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range n.Headers {
+		req.Header.Set(key, value)
+	}
+
+	httpClient := n.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+func (c *Client) notify(ctx context.Context, result BatchResult) {
+	if c.opts.Notifier == nil {
+		return
+	}
+	if err := c.opts.Notifier.Notify(context.WithoutCancel(ctx), result); err != nil && c.opts.NotifyErrors != nil {
+		c.opts.NotifyErrors(result, err)
+	}
+}
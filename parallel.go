@@ -0,0 +1,120 @@
+package caption
+
+import "strings"
+
+const (
+	sentencePauseSeconds = 1.0
+	alignmentTolerance   = 0.3
+)
+
+type Sentence struct {
+	Start float64
+	End   float64
+	Text  string
+}
+
+type ParallelSentence struct {
+	Start  float64
+	End    float64
+	Source string
+	Target string
+	Score  float64
+}
+
+func endsSentence(word string) bool {
+	word = strings.TrimRight(word, `"'”’)]»`)
+	return strings.HasSuffix(word, ".") || strings.HasSuffix(word, "!") || strings.HasSuffix(word, "?") ||
+		strings.HasSuffix(word, "。") || strings.HasSuffix(word, "！") || strings.HasSuffix(word, "？")
+}
+
+func (c *Caption) Sentences() []Sentence {
+	var sentences []Sentence
+	var current []string
+	var start, end float64
+	flush := func() {
+		if len(current) > 0 {
+			sentences = append(sentences, Sentence{Start: start, End: end, Text: strings.Join(current, " ")})
+			current = nil
+		}
+	}
+
+	subs := c.GetSubtitleText()
+	for i, sub := range subs {
+		if i > 0 && sub.StartTime-subs[i-1].EndTime >= sentencePauseSeconds {
+			flush()
+		}
+		words := strings.Fields(sub.Text)
+		duration := max(sub.EndTime-sub.StartTime, 0)
+		for k, word := range words {
+			if len(current) == 0 {
+				start = sub.StartTime + duration*float64(k)/float64(len(words))
+			}
+			current = append(current, word)
+			end = sub.StartTime + duration*float64(k+1)/float64(len(words))
+			if endsSentence(word) {
+				flush()
+			}
+		}
+	}
+	flush()
+	return sentences
+}
+
+func ExtractParallelSentences(a, b *Caption) []ParallelSentence {
+	source, target := a.Sentences(), b.Sentences()
+	var pairs []ParallelSentence
+	i, j := 0, 0
+	for i < len(source) && j < len(target) {
+		if !overlapping(source[i], target[j]) {
+			if source[i].End <= target[j].End {
+				i++
+			} else {
+				j++
+			}
+			continue
+		}
+
+		fromI, fromJ := i, j
+		end := max(source[i].End, target[j].End)
+		i, j = i+1, j+1
+		for {
+			if i < len(source) && source[i].Start < end-alignmentTolerance {
+				end = max(end, source[i].End)
+				i++
+			} else if j < len(target) && target[j].Start < end-alignmentTolerance {
+				end = max(end, target[j].End)
+				j++
+			} else {
+				break
+			}
+		}
+		pairs = append(pairs, parallelPair(source[fromI:i], target[fromJ:j]))
+	}
+	return pairs
+}
+
+func overlapping(a, b Sentence) bool {
+	return a.Start < b.End-alignmentTolerance && b.Start < a.End-alignmentTolerance
+}
+
+func parallelPair(source, target []Sentence) ParallelSentence {
+	join := func(sentences []Sentence) string {
+		texts := make([]string, len(sentences))
+		for i, s := range sentences {
+			texts[i] = s.Text
+		}
+		return strings.Join(texts, " ")
+	}
+	sourceStart, sourceEnd := source[0].Start, source[len(source)-1].End
+	targetStart, targetEnd := target[0].Start, target[len(target)-1].End
+	pair := ParallelSentence{
+		Start:  min(sourceStart, targetStart),
+		End:    max(sourceEnd, targetEnd),
+		Source: join(source),
+		Target: join(target),
+	}
+	if union := pair.End - pair.Start; union > 0 {
+		pair.Score = max(0, min(sourceEnd, targetEnd)-max(sourceStart, targetStart)) / union
+	}
+	return pair
+}
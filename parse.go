@@ -0,0 +1,154 @@
+package caption
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func parseTimingLine(line string) (float64, float64, bool) {
+	start, rest, ok := strings.Cut(line, "-->")
+	if !ok {
+		return 0, 0, false
+	}
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return 0, 0, false
+	}
+	startTime, err := parseTimecode(start)
+	if err != nil {
+		return 0, 0, false
+	}
+	endTime, err := parseTimecode(fields[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	return startTime, endTime, true
+}
+
+func parseCueBlocks(r io.Reader) ([]SubtitleText, error) {
+	var subs []SubtitleText
+	var block []string
+	flush := func() {
+		defer func() { block = block[:0] }()
+		for i, line := range block {
+			start, end, ok := parseTimingLine(line)
+			if !ok {
+				continue
+			}
+			text, spans := parseMarkup(strings.Join(block[i+1:], "\n"))
+			if text != "" {
+				subs = append(subs, SubtitleText{StartTime: start, EndTime: end, Text: text, Spans: spans})
+			}
+			return
+		}
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(subs) == 0 && len(block) == 0 {
+			line = strings.TrimPrefix(line, "\uFEFF")
+		}
+		if strings.TrimSpace(line) == "" {
+			flush()
+			continue
+		}
+		block = append(block, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+	return subs, nil
+}
+
+func ParseSRT(r io.Reader) (*Caption, error) {
+	subs, err := parseCueBlocks(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SRT: %w", err)
+	}
+	return (&Caption{}).withSubtitles(subs), nil
+}
+
+func ParseVTT(r io.Reader) (*Caption, error) {
+	br := bufio.NewReader(r)
+	header, _ := br.Peek(9)
+	if !bytes.HasPrefix(bytes.TrimPrefix(header, []byte("\xef\xbb\xbf")), []byte("WEBVTT")) {
+		return nil, fmt.Errorf("failed to parse VTT: missing WEBVTT header")
+	}
+	subs, err := parseCueBlocks(br)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse VTT: %w", err)
+	}
+	return (&Caption{}).withSubtitles(subs), nil
+}
+
+func Parse(r io.Reader, format Format) (*Caption, error) {
+	return ParseContext(context.Background(), r, format)
+}
+
+func ParseContext(ctx context.Context, r io.Reader, format Format) (*Caption, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r = &contextReader{ctx: ctx, r: r}
+	switch format {
+	case FormatSRT:
+		return ParseSRT(r)
+	case FormatVTT:
+		return ParseVTT(r)
+	case FormatXLIFF:
+		return ParseXLIFF(r)
+	case FormatTMX:
+		return ParseTMX(r, "")
+	case FormatJSON, FormatCues:
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		if format == FormatCues {
+			return ParseExport(data)
+		}
+		return parseJSON(data)
+	default:
+		return nil, fmt.Errorf("unsupported input format: %q", format)
+	}
+}
+
+func detectFormat(filename string) (Format, error) {
+	name := strings.ToLower(filename)
+	switch {
+	case strings.HasSuffix(name, FormatCues.Ext()):
+		return FormatCues, nil
+	case strings.HasSuffix(name, FormatChapters.Ext()):
+		return FormatChapters, nil
+	case filepath.Ext(name) == "":
+		return "", fmt.Errorf("cannot detect format of %s", filename)
+	default:
+		return ParseFormat(filepath.Ext(name))
+	}
+}
+
+func LoadFile(filename string) (*Caption, error) {
+	return LoadFileContext(context.Background(), filename)
+}
+
+func LoadFileContext(ctx context.Context, filename string) (*Caption, error) {
+	format, err := detectFormat(filename)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	return ParseContext(ctx, f, format)
+}
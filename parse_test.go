@@ -0,0 +1,87 @@
+package caption
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const roundTripSRT = `1
+00:00:01,001 --> 00:00:02,999
+Hello <b>bold</b> world
+
+2
+00:00:03,457 --> 00:00:05,012
+first line
+<i>second line</i>
+
+3
+01:02:03,004 --> 01:02:04,567
+<b><i>both</i></b> and plain
+`
+
+func TestParseRoundTrip(t *testing.T) {
+	original, err := ParseSRT(strings.NewReader(roundTripSRT))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := original.GetSubtitleText()
+	if len(want) != 3 {
+		t.Fatalf("parsed %d cues, want 3", len(want))
+	}
+	for _, format := range []Format{FormatJSON, FormatSRT, FormatVTT} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := original.Write(&buf, format); err != nil {
+				t.Fatal(err)
+			}
+			parsed, err := Parse(&buf, format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := parsed.GetSubtitleText()
+			if len(got) != len(want) {
+				t.Fatalf("got %d cues, want %d", len(got), len(want))
+			}
+			for i := range want {
+				if got[i].Text != want[i].Text {
+					t.Errorf("cue %d text = %q, want %q", i, got[i].Text, want[i].Text)
+				}
+				if !reflect.DeepEqual(got[i].Spans, want[i].Spans) {
+					t.Errorf("cue %d spans = %+v, want %+v", i, got[i].Spans, want[i].Spans)
+				}
+				if math.Abs(got[i].StartTime-want[i].StartTime) > 0.001 || math.Abs(got[i].EndTime-want[i].EndTime) > 0.001 {
+					t.Errorf("cue %d timing = %v-%v, want %v-%v", i, got[i].StartTime, got[i].EndTime, want[i].StartTime, want[i].EndTime)
+				}
+			}
+		})
+	}
+}
+
+func TestParseStripsVoiceClassAndFontTags(t *testing.T) {
+	vtt := "WEBVTT\n\n00:00:01.000 --> 00:00:02.000\n<v Roger Bingham>We are <c.loud>in</c> <i>New York</i></v>\n\n" +
+		"00:00:02.000 --> 00:00:03.000\n<00:00:02.100><c>karaoke</c> <lang en>text</lang>\n"
+	c, err := ParseVTT(strings.NewReader(vtt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	subs := c.GetSubtitleText()
+	if len(subs) != 2 || subs[0].Text != "We are in New York" || subs[1].Text != "karaoke text" {
+		t.Fatalf("unexpected cues: %+v", subs)
+	}
+	want := []StyledSpan{{Text: "We are in "}, {Text: "New York", Style: StyleItalic}}
+	if !reflect.DeepEqual(subs[0].Spans, want) {
+		t.Errorf("spans = %+v, want %+v", subs[0].Spans, want)
+	}
+
+	srt := "1\n00:00:01,000 --> 00:00:02,000\n<font color=\"#ffff00\">yellow</font> <FONT face=\"Arial\">text</FONT> 1 < 2\n"
+	c, err = ParseSRT(strings.NewReader(srt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subs := c.GetSubtitleText(); len(subs) != 1 || subs[0].Text != "yellow text 1 < 2" || subs[0].Spans != nil {
+		t.Fatalf("unexpected cues: %+v", subs)
+	}
+}
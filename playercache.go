@@ -0,0 +1,59 @@
+package caption
+
+import (
+	"sync"
+	"time"
+)
+
+const defaultPlayerCacheTTL = time.Minute
+
+type playerCacheEntry struct {
+	resp    *playerResponse
+	expires time.Time
+}
+
+type playerCache struct {
+	mu      sync.Mutex
+	entries map[string]playerCacheEntry
+	clock   Clock
+}
+
+func newPlayerCache(clock Clock) *playerCache {
+	return &playerCache{entries: make(map[string]playerCacheEntry), clock: clock}
+}
+
+func (pc *playerCache) get(key string) (*playerResponse, bool) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	entry, ok := pc.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if pc.clock.Now().After(entry.expires) {
+		delete(pc.entries, key)
+		return nil, false
+	}
+	return entry.resp, true
+}
+
+func (pc *playerCache) put(key string, resp *playerResponse, ttl time.Duration) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	now := pc.clock.Now()
+	for k, entry := range pc.entries {
+		if now.After(entry.expires) {
+			delete(pc.entries, k)
+		}
+	}
+	pc.entries[key] = playerCacheEntry{resp: resp, expires: now.Add(ttl)}
+}
+
+func (pc *playerCache) clear() {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	clear(pc.entries)
+}
+
+func (c *Client) playerCacheKey(videoID string) string {
+	return videoID + "\x00" + c.opts.Region + "\x00" + c.opts.OAuthToken + "\x00" + c.opts.Cookies
+}
@@ -0,0 +1,58 @@
+package caption
+
+import (
+	"sort"
+	"time"
+)
+
+func (c *Caption) Preview(n int) []SubtitleText {
+	subs := c.GetSubtitleText()
+	if n <= 0 || len(subs) == 0 {
+		return nil
+	}
+	if n >= len(subs) {
+		return subs
+	}
+	if n == 1 {
+		return subs[:1]
+	}
+	preview := make([]SubtitleText, n)
+	for i := range preview {
+		preview[i] = subs[i*(len(subs)-1)/(n-1)]
+	}
+	return preview
+}
+
+func activeCue(subs []SubtitleText, seconds float64) (int, int) {
+	next := sort.Search(len(subs), func(i int) bool {
+		return subs[i].StartTime > seconds
+	})
+	for j := next - 1; j >= 0; j-- {
+		if seconds < subs[j].EndTime || seconds == subs[j].StartTime {
+			return j, next
+		}
+	}
+	return -1, next
+}
+
+func (c *Caption) At(t time.Duration) (SubtitleText, int, bool) {
+	subs := c.GetSubtitleText()
+	if i, _ := activeCue(subs, t.Seconds()); i >= 0 {
+		return subs[i], i, true
+	}
+	return SubtitleText{}, -1, false
+}
+
+func (c *Caption) Window(t time.Duration, before, after int) ([]SubtitleText, int) {
+	subs := c.GetSubtitleText()
+	if len(subs) == 0 {
+		return nil, -1
+	}
+	focus, next := activeCue(subs, t.Seconds())
+	if focus < 0 {
+		focus = min(next, len(subs)-1)
+	}
+	from := max(focus-max(before, 0), 0)
+	to := min(focus+max(after, 0)+1, len(subs))
+	return subs[from:to], focus - from
+}
@@ -0,0 +1,751 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: caption/v1/caption.proto
+
+package captionv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Track struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BaseUrl       string                 `protobuf:"bytes,1,opt,name=base_url,json=baseUrl,proto3" json:"base_url,omitempty"`
+	LanguageCode  string                 `protobuf:"bytes,2,opt,name=language_code,json=languageCode,proto3" json:"language_code,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Kind          string                 `protobuf:"bytes,4,opt,name=kind,proto3" json:"kind,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Track) Reset() {
+	*x = Track{}
+	mi := &file_caption_v1_caption_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Track) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Track) ProtoMessage() {}
+
+func (x *Track) ProtoReflect() protoreflect.Message {
+	mi := &file_caption_v1_caption_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Track.ProtoReflect.Descriptor instead.
+func (*Track) Descriptor() ([]byte, []int) {
+	return file_caption_v1_caption_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Track) GetBaseUrl() string {
+	if x != nil {
+		return x.BaseUrl
+	}
+	return ""
+}
+
+func (x *Track) GetLanguageCode() string {
+	if x != nil {
+		return x.LanguageCode
+	}
+	return ""
+}
+
+func (x *Track) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Track) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+type Cue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartTime     float64                `protobuf:"fixed64,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       float64                `protobuf:"fixed64,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Cue) Reset() {
+	*x = Cue{}
+	mi := &file_caption_v1_caption_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Cue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cue) ProtoMessage() {}
+
+func (x *Cue) ProtoReflect() protoreflect.Message {
+	mi := &file_caption_v1_caption_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cue.ProtoReflect.Descriptor instead.
+func (*Cue) Descriptor() ([]byte, []int) {
+	return file_caption_v1_caption_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Cue) GetStartTime() float64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *Cue) GetEndTime() float64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *Cue) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type ListTracksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTracksRequest) Reset() {
+	*x = ListTracksRequest{}
+	mi := &file_caption_v1_caption_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTracksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTracksRequest) ProtoMessage() {}
+
+func (x *ListTracksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_caption_v1_caption_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTracksRequest.ProtoReflect.Descriptor instead.
+func (*ListTracksRequest) Descriptor() ([]byte, []int) {
+	return file_caption_v1_caption_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListTracksRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+type ListTracksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tracks        []*Track               `protobuf:"bytes,1,rep,name=tracks,proto3" json:"tracks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTracksResponse) Reset() {
+	*x = ListTracksResponse{}
+	mi := &file_caption_v1_caption_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTracksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTracksResponse) ProtoMessage() {}
+
+func (x *ListTracksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_caption_v1_caption_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTracksResponse.ProtoReflect.Descriptor instead.
+func (*ListTracksResponse) Descriptor() ([]byte, []int) {
+	return file_caption_v1_caption_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListTracksResponse) GetTracks() []*Track {
+	if x != nil {
+		return x.Tracks
+	}
+	return nil
+}
+
+type GetCaptionRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	VideoId  string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	Language string                 `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	Kind     string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	// One of "json", "srt", "vtt", "txt". Empty returns cues only.
+	Format        string `protobuf:"bytes,4,opt,name=format,proto3" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCaptionRequest) Reset() {
+	*x = GetCaptionRequest{}
+	mi := &file_caption_v1_caption_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCaptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCaptionRequest) ProtoMessage() {}
+
+func (x *GetCaptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_caption_v1_caption_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCaptionRequest.ProtoReflect.Descriptor instead.
+func (*GetCaptionRequest) Descriptor() ([]byte, []int) {
+	return file_caption_v1_caption_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetCaptionRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *GetCaptionRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *GetCaptionRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *GetCaptionRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+type GetCaptionResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Track *Track                 `protobuf:"bytes,1,opt,name=track,proto3" json:"track,omitempty"`
+	Cues  []*Cue                 `protobuf:"bytes,2,rep,name=cues,proto3" json:"cues,omitempty"`
+	// Rendered export when a format was requested.
+	Content       []byte `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCaptionResponse) Reset() {
+	*x = GetCaptionResponse{}
+	mi := &file_caption_v1_caption_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCaptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCaptionResponse) ProtoMessage() {}
+
+func (x *GetCaptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_caption_v1_caption_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCaptionResponse.ProtoReflect.Descriptor instead.
+func (*GetCaptionResponse) Descriptor() ([]byte, []int) {
+	return file_caption_v1_caption_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetCaptionResponse) GetTrack() *Track {
+	if x != nil {
+		return x.Track
+	}
+	return nil
+}
+
+func (x *GetCaptionResponse) GetCues() []*Cue {
+	if x != nil {
+		return x.Cues
+	}
+	return nil
+}
+
+func (x *GetCaptionResponse) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+// Wire format of Caption.MarshalBinary / Caption.UnmarshalBinary.
+type CaptionData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*CaptionData_Event   `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	VideoId       string                 `protobuf:"bytes,2,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	Pens          []*CaptionData_Pen     `protobuf:"bytes,3,rep,name=pens,proto3" json:"pens,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CaptionData) Reset() {
+	*x = CaptionData{}
+	mi := &file_caption_v1_caption_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaptionData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaptionData) ProtoMessage() {}
+
+func (x *CaptionData) ProtoReflect() protoreflect.Message {
+	mi := &file_caption_v1_caption_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaptionData.ProtoReflect.Descriptor instead.
+func (*CaptionData) Descriptor() ([]byte, []int) {
+	return file_caption_v1_caption_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CaptionData) GetEvents() []*CaptionData_Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *CaptionData) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *CaptionData) GetPens() []*CaptionData_Pen {
+	if x != nil {
+		return x.Pens
+	}
+	return nil
+}
+
+type CaptionData_Segment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Utf8          string                 `protobuf:"bytes,1,opt,name=utf8,proto3" json:"utf8,omitempty"`
+	TOffsetMs     int64                  `protobuf:"varint,2,opt,name=t_offset_ms,json=tOffsetMs,proto3" json:"t_offset_ms,omitempty"`
+	AcAsrConf     int64                  `protobuf:"varint,3,opt,name=ac_asr_conf,json=acAsrConf,proto3" json:"ac_asr_conf,omitempty"`
+	PenId         int64                  `protobuf:"varint,4,opt,name=pen_id,json=penId,proto3" json:"pen_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CaptionData_Segment) Reset() {
+	*x = CaptionData_Segment{}
+	mi := &file_caption_v1_caption_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaptionData_Segment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaptionData_Segment) ProtoMessage() {}
+
+func (x *CaptionData_Segment) ProtoReflect() protoreflect.Message {
+	mi := &file_caption_v1_caption_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaptionData_Segment.ProtoReflect.Descriptor instead.
+func (*CaptionData_Segment) Descriptor() ([]byte, []int) {
+	return file_caption_v1_caption_proto_rawDescGZIP(), []int{6, 0}
+}
+
+func (x *CaptionData_Segment) GetUtf8() string {
+	if x != nil {
+		return x.Utf8
+	}
+	return ""
+}
+
+func (x *CaptionData_Segment) GetTOffsetMs() int64 {
+	if x != nil {
+		return x.TOffsetMs
+	}
+	return 0
+}
+
+func (x *CaptionData_Segment) GetAcAsrConf() int64 {
+	if x != nil {
+		return x.AcAsrConf
+	}
+	return 0
+}
+
+func (x *CaptionData_Segment) GetPenId() int64 {
+	if x != nil {
+		return x.PenId
+	}
+	return 0
+}
+
+type CaptionData_Event struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TStartMs      int64                  `protobuf:"varint,1,opt,name=t_start_ms,json=tStartMs,proto3" json:"t_start_ms,omitempty"`
+	Segs          []*CaptionData_Segment `protobuf:"bytes,2,rep,name=segs,proto3" json:"segs,omitempty"`
+	PenId         int64                  `protobuf:"varint,3,opt,name=pen_id,json=penId,proto3" json:"pen_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CaptionData_Event) Reset() {
+	*x = CaptionData_Event{}
+	mi := &file_caption_v1_caption_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaptionData_Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaptionData_Event) ProtoMessage() {}
+
+func (x *CaptionData_Event) ProtoReflect() protoreflect.Message {
+	mi := &file_caption_v1_caption_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaptionData_Event.ProtoReflect.Descriptor instead.
+func (*CaptionData_Event) Descriptor() ([]byte, []int) {
+	return file_caption_v1_caption_proto_rawDescGZIP(), []int{6, 1}
+}
+
+func (x *CaptionData_Event) GetTStartMs() int64 {
+	if x != nil {
+		return x.TStartMs
+	}
+	return 0
+}
+
+func (x *CaptionData_Event) GetSegs() []*CaptionData_Segment {
+	if x != nil {
+		return x.Segs
+	}
+	return nil
+}
+
+func (x *CaptionData_Event) GetPenId() int64 {
+	if x != nil {
+		return x.PenId
+	}
+	return 0
+}
+
+// Index into pens; mirrors the json3 pens/pPenId formatting (bold, italic, ruby).
+type CaptionData_Pen struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bold          int64                  `protobuf:"varint,1,opt,name=bold,proto3" json:"bold,omitempty"`
+	Italic        int64                  `protobuf:"varint,2,opt,name=italic,proto3" json:"italic,omitempty"`
+	Underline     int64                  `protobuf:"varint,3,opt,name=underline,proto3" json:"underline,omitempty"`
+	Ruby          int64                  `protobuf:"varint,4,opt,name=ruby,proto3" json:"ruby,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CaptionData_Pen) Reset() {
+	*x = CaptionData_Pen{}
+	mi := &file_caption_v1_caption_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaptionData_Pen) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaptionData_Pen) ProtoMessage() {}
+
+func (x *CaptionData_Pen) ProtoReflect() protoreflect.Message {
+	mi := &file_caption_v1_caption_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaptionData_Pen.ProtoReflect.Descriptor instead.
+func (*CaptionData_Pen) Descriptor() ([]byte, []int) {
+	return file_caption_v1_caption_proto_rawDescGZIP(), []int{6, 2}
+}
+
+func (x *CaptionData_Pen) GetBold() int64 {
+	if x != nil {
+		return x.Bold
+	}
+	return 0
+}
+
+func (x *CaptionData_Pen) GetItalic() int64 {
+	if x != nil {
+		return x.Italic
+	}
+	return 0
+}
+
+func (x *CaptionData_Pen) GetUnderline() int64 {
+	if x != nil {
+		return x.Underline
+	}
+	return 0
+}
+
+func (x *CaptionData_Pen) GetRuby() int64 {
+	if x != nil {
+		return x.Ruby
+	}
+	return 0
+}
+
+var File_caption_v1_caption_proto protoreflect.FileDescriptor
+
+const file_caption_v1_caption_proto_rawDesc = "" +
+	"\n" +
+	"\x18caption/v1/caption.proto\x12\n" +
+	"caption.v1\"o\n" +
+	"\x05Track\x12\x19\n" +
+	"\bbase_url\x18\x01 \x01(\tR\abaseUrl\x12#\n" +
+	"\rlanguage_code\x18\x02 \x01(\tR\flanguageCode\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x12\n" +
+	"\x04kind\x18\x04 \x01(\tR\x04kind\"S\n" +
+	"\x03Cue\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x01 \x01(\x01R\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x02 \x01(\x01R\aendTime\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\".\n" +
+	"\x11ListTracksRequest\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\"?\n" +
+	"\x12ListTracksResponse\x12)\n" +
+	"\x06tracks\x18\x01 \x03(\v2\x11.caption.v1.TrackR\x06tracks\"v\n" +
+	"\x11GetCaptionRequest\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x1a\n" +
+	"\blanguage\x18\x02 \x01(\tR\blanguage\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x16\n" +
+	"\x06format\x18\x04 \x01(\tR\x06format\"|\n" +
+	"\x12GetCaptionResponse\x12'\n" +
+	"\x05track\x18\x01 \x01(\v2\x11.caption.v1.TrackR\x05track\x12#\n" +
+	"\x04cues\x18\x02 \x03(\v2\x0f.caption.v1.CueR\x04cues\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\fR\acontent\"\xde\x03\n" +
+	"\vCaptionData\x125\n" +
+	"\x06events\x18\x01 \x03(\v2\x1d.caption.v1.CaptionData.EventR\x06events\x12\x19\n" +
+	"\bvideo_id\x18\x02 \x01(\tR\avideoId\x12/\n" +
+	"\x04pens\x18\x03 \x03(\v2\x1b.caption.v1.CaptionData.PenR\x04pens\x1at\n" +
+	"\aSegment\x12\x12\n" +
+	"\x04utf8\x18\x01 \x01(\tR\x04utf8\x12\x1e\n" +
+	"\vt_offset_ms\x18\x02 \x01(\x03R\ttOffsetMs\x12\x1e\n" +
+	"\vac_asr_conf\x18\x03 \x01(\x03R\tacAsrConf\x12\x15\n" +
+	"\x06pen_id\x18\x04 \x01(\x03R\x05penId\x1aq\n" +
+	"\x05Event\x12\x1c\n" +
+	"\n" +
+	"t_start_ms\x18\x01 \x01(\x03R\btStartMs\x123\n" +
+	"\x04segs\x18\x02 \x03(\v2\x1f.caption.v1.CaptionData.SegmentR\x04segs\x12\x15\n" +
+	"\x06pen_id\x18\x03 \x01(\x03R\x05penId\x1ac\n" +
+	"\x03Pen\x12\x12\n" +
+	"\x04bold\x18\x01 \x01(\x03R\x04bold\x12\x16\n" +
+	"\x06italic\x18\x02 \x01(\x03R\x06italic\x12\x1c\n" +
+	"\tunderline\x18\x03 \x01(\x03R\tunderline\x12\x12\n" +
+	"\x04ruby\x18\x04 \x01(\x03R\x04ruby2\xea\x01\n" +
+	"\x0eCaptionService\x12K\n" +
+	"\n" +
+	"ListTracks\x12\x1d.caption.v1.ListTracksRequest\x1a\x1e.caption.v1.ListTracksResponse\x12K\n" +
+	"\n" +
+	"GetCaption\x12\x1d.caption.v1.GetCaptionRequest\x1a\x1e.caption.v1.GetCaptionResponse\x12>\n" +
+	"\n" +
+	"StreamCues\x12\x1d.caption.v1.GetCaptionRequest\x1a\x0f.caption.v1.Cue0\x01BBZ@github.com/lincaiyong/youtube-caption/proto/caption/v1;captionv1b\x06proto3"
+
+var (
+	file_caption_v1_caption_proto_rawDescOnce sync.Once
+	file_caption_v1_caption_proto_rawDescData []byte
+)
+
+func file_caption_v1_caption_proto_rawDescGZIP() []byte {
+	file_caption_v1_caption_proto_rawDescOnce.Do(func() {
+		file_caption_v1_caption_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_caption_v1_caption_proto_rawDesc), len(file_caption_v1_caption_proto_rawDesc)))
+	})
+	return file_caption_v1_caption_proto_rawDescData
+}
+
+var file_caption_v1_caption_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_caption_v1_caption_proto_goTypes = []any{
+	(*Track)(nil),               // 0: caption.v1.Track
+	(*Cue)(nil),                 // 1: caption.v1.Cue
+	(*ListTracksRequest)(nil),   // 2: caption.v1.ListTracksRequest
+	(*ListTracksResponse)(nil),  // 3: caption.v1.ListTracksResponse
+	(*GetCaptionRequest)(nil),   // 4: caption.v1.GetCaptionRequest
+	(*GetCaptionResponse)(nil),  // 5: caption.v1.GetCaptionResponse
+	(*CaptionData)(nil),         // 6: caption.v1.CaptionData
+	(*CaptionData_Segment)(nil), // 7: caption.v1.CaptionData.Segment
+	(*CaptionData_Event)(nil),   // 8: caption.v1.CaptionData.Event
+	(*CaptionData_Pen)(nil),     // 9: caption.v1.CaptionData.Pen
+}
+var file_caption_v1_caption_proto_depIdxs = []int32{
+	0, // 0: caption.v1.ListTracksResponse.tracks:type_name -> caption.v1.Track
+	0, // 1: caption.v1.GetCaptionResponse.track:type_name -> caption.v1.Track
+	1, // 2: caption.v1.GetCaptionResponse.cues:type_name -> caption.v1.Cue
+	8, // 3: caption.v1.CaptionData.events:type_name -> caption.v1.CaptionData.Event
+	9, // 4: caption.v1.CaptionData.pens:type_name -> caption.v1.CaptionData.Pen
+	7, // 5: caption.v1.CaptionData.Event.segs:type_name -> caption.v1.CaptionData.Segment
+	2, // 6: caption.v1.CaptionService.ListTracks:input_type -> caption.v1.ListTracksRequest
+	4, // 7: caption.v1.CaptionService.GetCaption:input_type -> caption.v1.GetCaptionRequest
+	4, // 8: caption.v1.CaptionService.StreamCues:input_type -> caption.v1.GetCaptionRequest
+	3, // 9: caption.v1.CaptionService.ListTracks:output_type -> caption.v1.ListTracksResponse
+	5, // 10: caption.v1.CaptionService.GetCaption:output_type -> caption.v1.GetCaptionResponse
+	1, // 11: caption.v1.CaptionService.StreamCues:output_type -> caption.v1.Cue
+	9, // [9:12] is the sub-list for method output_type
+	6, // [6:9] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_caption_v1_caption_proto_init() }
+func file_caption_v1_caption_proto_init() {
+	if File_caption_v1_caption_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_caption_v1_caption_proto_rawDesc), len(file_caption_v1_caption_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_caption_v1_caption_proto_goTypes,
+		DependencyIndexes: file_caption_v1_caption_proto_depIdxs,
+		MessageInfos:      file_caption_v1_caption_proto_msgTypes,
+	}.Build()
+	File_caption_v1_caption_proto = out.File
+	file_caption_v1_caption_proto_goTypes = nil
+	file_caption_v1_caption_proto_depIdxs = nil
+}
@@ -0,0 +1,201 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: caption/v1/caption.proto
+
+package captionv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CaptionService_ListTracks_FullMethodName = "/caption.v1.CaptionService/ListTracks"
+	CaptionService_GetCaption_FullMethodName = "/caption.v1.CaptionService/GetCaption"
+	CaptionService_StreamCues_FullMethodName = "/caption.v1.CaptionService/StreamCues"
+)
+
+// CaptionServiceClient is the client API for CaptionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CaptionServiceClient interface {
+	ListTracks(ctx context.Context, in *ListTracksRequest, opts ...grpc.CallOption) (*ListTracksResponse, error)
+	GetCaption(ctx context.Context, in *GetCaptionRequest, opts ...grpc.CallOption) (*GetCaptionResponse, error)
+	StreamCues(ctx context.Context, in *GetCaptionRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Cue], error)
+}
+
+type captionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCaptionServiceClient(cc grpc.ClientConnInterface) CaptionServiceClient {
+	return &captionServiceClient{cc}
+}
+
+func (c *captionServiceClient) ListTracks(ctx context.Context, in *ListTracksRequest, opts ...grpc.CallOption) (*ListTracksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTracksResponse)
+	err := c.cc.Invoke(ctx, CaptionService_ListTracks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *captionServiceClient) GetCaption(ctx context.Context, in *GetCaptionRequest, opts ...grpc.CallOption) (*GetCaptionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCaptionResponse)
+	err := c.cc.Invoke(ctx, CaptionService_GetCaption_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *captionServiceClient) StreamCues(ctx context.Context, in *GetCaptionRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Cue], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CaptionService_ServiceDesc.Streams[0], CaptionService_StreamCues_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetCaptionRequest, Cue]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CaptionService_StreamCuesClient = grpc.ServerStreamingClient[Cue]
+
+// CaptionServiceServer is the server API for CaptionService service.
+// All implementations must embed UnimplementedCaptionServiceServer
+// for forward compatibility.
+type CaptionServiceServer interface {
+	ListTracks(context.Context, *ListTracksRequest) (*ListTracksResponse, error)
+	GetCaption(context.Context, *GetCaptionRequest) (*GetCaptionResponse, error)
+	StreamCues(*GetCaptionRequest, grpc.ServerStreamingServer[Cue]) error
+	mustEmbedUnimplementedCaptionServiceServer()
+}
+
+// UnimplementedCaptionServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCaptionServiceServer struct{}
+
+func (UnimplementedCaptionServiceServer) ListTracks(context.Context, *ListTracksRequest) (*ListTracksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTracks not implemented")
+}
+func (UnimplementedCaptionServiceServer) GetCaption(context.Context, *GetCaptionRequest) (*GetCaptionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCaption not implemented")
+}
+func (UnimplementedCaptionServiceServer) StreamCues(*GetCaptionRequest, grpc.ServerStreamingServer[Cue]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamCues not implemented")
+}
+func (UnimplementedCaptionServiceServer) mustEmbedUnimplementedCaptionServiceServer() {}
+func (UnimplementedCaptionServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeCaptionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CaptionServiceServer will
+// result in compilation errors.
+type UnsafeCaptionServiceServer interface {
+	mustEmbedUnimplementedCaptionServiceServer()
+}
+
+func RegisterCaptionServiceServer(s grpc.ServiceRegistrar, srv CaptionServiceServer) {
+	// If the following call pancis, it indicates UnimplementedCaptionServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CaptionService_ServiceDesc, srv)
+}
+
+func _CaptionService_ListTracks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTracksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CaptionServiceServer).ListTracks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CaptionService_ListTracks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CaptionServiceServer).ListTracks(ctx, req.(*ListTracksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CaptionService_GetCaption_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCaptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CaptionServiceServer).GetCaption(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CaptionService_GetCaption_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CaptionServiceServer).GetCaption(ctx, req.(*GetCaptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CaptionService_StreamCues_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetCaptionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CaptionServiceServer).StreamCues(m, &grpc.GenericServerStream[GetCaptionRequest, Cue]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CaptionService_StreamCuesServer = grpc.ServerStreamingServer[Cue]
+
+// CaptionService_ServiceDesc is the grpc.ServiceDesc for CaptionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CaptionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "caption.v1.CaptionService",
+	HandlerType: (*CaptionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListTracks",
+			Handler:    _CaptionService_ListTracks_Handler,
+		},
+		{
+			MethodName: "GetCaption",
+			Handler:    _CaptionService_GetCaption_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamCues",
+			Handler:       _CaptionService_StreamCues_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "caption/v1/caption.proto",
+}
@@ -0,0 +1,45 @@
+package captionv1_test
+
+import (
+	"testing"
+
+	caption "github.com/lincaiyong/youtube-caption"
+	captionv1 "github.com/lincaiyong/youtube-caption/proto/caption/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestCaptionDataMatchesMarshalBinary(t *testing.T) {
+	c := &caption.Caption{
+		VideoID: "vStJoetOxJg",
+		Pens:    []caption.CaptionPen{{}, {Italic: 1}},
+		Events: []caption.CaptionEvent{{TStartMs: 1200, PenID: 1, Segments: []caption.CaptionSegment{
+			{UTF8: "hi", TOffsetMs: 300, AcAsrConf: 200, PenID: 1},
+		}}},
+	}
+	data, err := c.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var msg captionv1.CaptionData
+	if err = proto.Unmarshal(data, &msg); err != nil {
+		t.Fatal(err)
+	}
+	event := msg.GetEvents()[0]
+	seg := event.GetSegs()[0]
+	if msg.GetVideoId() != c.VideoID || len(msg.GetPens()) != 2 || msg.GetPens()[1].GetItalic() != 1 ||
+		event.GetTStartMs() != 1200 || event.GetPenId() != 1 || seg.GetUtf8() != "hi" || seg.GetPenId() != 1 {
+		t.Errorf("CaptionData = %v", &msg)
+	}
+
+	back, err := proto.Marshal(&msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var round caption.Caption
+	if err = round.UnmarshalBinary(back); err != nil {
+		t.Fatal(err)
+	}
+	if round.Events[0].Segments[0].PenID != 1 || round.Pens[1].Italic != 1 {
+		t.Errorf("UnmarshalBinary(proto.Marshal) = %+v", round)
+	}
+}
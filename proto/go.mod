@@ -0,0 +1,19 @@
+module github.com/lincaiyong/youtube-caption/proto
+
+go 1.24
+
+require (
+	github.com/lincaiyong/youtube-caption v0.0.0
+	google.golang.org/grpc v1.75.0
+	google.golang.org/protobuf v1.36.9
+)
+
+require (
+	github.com/cenkalti/backoff/v4 v4.2.1 // indirect
+	golang.org/x/net v0.41.0 // indirect
+	golang.org/x/sys v0.33.0 // indirect
+	golang.org/x/text v0.26.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250707201910-8d1bb00bc6a7 // indirect
+)
+
+replace github.com/lincaiyong/youtube-caption => ../
@@ -0,0 +1,133 @@
+package grpcserver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	caption "github.com/lincaiyong/youtube-caption"
+	captionv1 "github.com/lincaiyong/youtube-caption/proto/caption/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type Server struct {
+	captionv1.UnimplementedCaptionServiceServer
+	client *caption.Client
+	opts   *caption.Options
+}
+
+func New(opts *caption.Options) *Server {
+	if opts == nil {
+		opts = caption.DefaultOptions()
+	}
+	return &Server{client: caption.NewClient(opts), opts: opts}
+}
+
+func (s *Server) Register(r grpc.ServiceRegistrar) {
+	captionv1.RegisterCaptionServiceServer(r, s)
+}
+
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.client.Shutdown(ctx)
+}
+
+func (s *Server) ListTracks(ctx context.Context, req *captionv1.ListTracksRequest) (*captionv1.ListTracksResponse, error) {
+	tracks, err := s.client.GetAvailableTracks(ctx, req.GetVideoId())
+	if err != nil {
+		return nil, statusForError(err)
+	}
+	resp := &captionv1.ListTracksResponse{Tracks: make([]*captionv1.Track, 0, len(tracks))}
+	for i := range tracks {
+		resp.Tracks = append(resp.Tracks, trackMessage(&tracks[i]))
+	}
+	return resp, nil
+}
+
+func (s *Server) GetCaption(ctx context.Context, req *captionv1.GetCaptionRequest) (*captionv1.GetCaptionResponse, error) {
+	var format caption.Format
+	if req.GetFormat() != "" {
+		var err error
+		if format, err = caption.ParseFormat(req.GetFormat()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	c, err := s.clientFor(req).Download(ctx, req.GetVideoId())
+	if err != nil {
+		return nil, statusForError(err)
+	}
+	resp := &captionv1.GetCaptionResponse{}
+	if c.Track != nil {
+		resp.Track = trackMessage(c.Track)
+	}
+	for _, sub := range c.GetSubtitleText() {
+		resp.Cues = append(resp.Cues, cueMessage(sub))
+	}
+	if format != "" {
+		var buf bytes.Buffer
+		if err = c.Write(&buf, format); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		resp.Content = buf.Bytes()
+	}
+	return resp, nil
+}
+
+func (s *Server) StreamCues(req *captionv1.GetCaptionRequest, stream grpc.ServerStreamingServer[captionv1.Cue]) error {
+	err := s.clientFor(req).DownloadStream(stream.Context(), req.GetVideoId(), func(sub caption.SubtitleText) error {
+		return stream.Send(cueMessage(sub))
+	})
+	if err != nil {
+		return statusForError(err)
+	}
+	return nil
+}
+
+func (s *Server) clientFor(req *captionv1.GetCaptionRequest) *caption.Client {
+	opts := *s.opts
+	if req.GetLanguage() != "" {
+		opts.Language = req.GetLanguage()
+	}
+	if req.GetKind() != "" {
+		opts.Kind = req.GetKind()
+	}
+	return s.client.With(&opts)
+}
+
+func trackMessage(track *caption.CaptionTrack) *captionv1.Track {
+	return &captionv1.Track{
+		BaseUrl:      track.BaseURL.URL,
+		LanguageCode: track.LanguageCode,
+		Name:         track.Name.SimpleText,
+		Kind:         track.Kind,
+	}
+}
+
+func cueMessage(sub caption.SubtitleText) *captionv1.Cue {
+	return &captionv1.Cue{StartTime: sub.StartTime, EndTime: sub.EndTime, Text: sub.Text}
+}
+
+func statusForError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+	code := codes.Unavailable
+	switch {
+	case errors.Is(err, caption.ErrInvalidVideoID):
+		code = codes.InvalidArgument
+	case errors.Is(err, caption.ErrNoCaptionsFound):
+		code = codes.NotFound
+	case errors.Is(err, caption.ErrLiveInProgress), errors.Is(err, caption.ErrNotYetAvailable):
+		code = codes.FailedPrecondition
+	case errors.Is(err, caption.ErrRegionBlocked):
+		code = codes.PermissionDenied
+	case errors.Is(err, caption.ErrRateLimited):
+		code = codes.ResourceExhausted
+	}
+	return status.Error(code, err.Error())
+}
@@ -0,0 +1,53 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	captionv1 "github.com/lincaiyong/youtube-caption/proto/caption/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func dial(t *testing.T) captionv1.CaptionServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	New(nil).Register(srv)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return captionv1.NewCaptionServiceClient(conn)
+}
+
+func TestInvalidArguments(t *testing.T) {
+	client := dial(t)
+	ctx := context.Background()
+
+	_, err := client.ListTracks(ctx, &captionv1.ListTracksRequest{VideoId: "not a video"})
+	if got := status.Code(err); got != codes.InvalidArgument {
+		t.Errorf("ListTracks code = %v, want InvalidArgument (%v)", got, err)
+	}
+	_, err = client.GetCaption(ctx, &captionv1.GetCaptionRequest{VideoId: "vStJoetOxJg", Format: "docx"})
+	if got := status.Code(err); got != codes.InvalidArgument {
+		t.Errorf("GetCaption code = %v, want InvalidArgument (%v)", got, err)
+	}
+	stream, err := client.StreamCues(ctx, &captionv1.GetCaptionRequest{VideoId: "bad"})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if got := status.Code(err); got != codes.InvalidArgument {
+		t.Errorf("StreamCues code = %v, want InvalidArgument (%v)", got, err)
+	}
+}
@@ -0,0 +1,91 @@
+package caption
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	punctuationChunkWords = 150
+	punctuationChunkGap   = 1.5
+)
+
+var ErrPunctuatorMismatch = errors.New("punctuator changed the word count")
+
+type Punctuator interface {
+	Punctuate(ctx context.Context, text string) (string, error)
+}
+
+type PunctuatorFunc func(ctx context.Context, text string) (string, error)
+
+func (f PunctuatorFunc) Punctuate(ctx context.Context, text string) (string, error) {
+	return f(ctx, text)
+}
+
+type punctuationWord struct {
+	cue, line int
+}
+
+func (c *Caption) RestorePunctuation(ctx context.Context, p Punctuator) (*Caption, error) {
+	subs := c.GetSubtitleText()
+	lines := make([][][]string, len(subs))
+	for i, sub := range subs {
+		for _, line := range strings.Split(sub.Text, "\n") {
+			lines[i] = append(lines[i], strings.Fields(line))
+		}
+	}
+
+	var chunk []string
+	var positions []punctuationWord
+	flush := func() error {
+		if len(chunk) == 0 {
+			return nil
+		}
+		punctuated, err := p.Punctuate(ctx, strings.Join(chunk, " "))
+		if err != nil {
+			return fmt.Errorf("failed to punctuate: %w", err)
+		}
+		words := strings.Fields(punctuated)
+		if len(words) != len(chunk) {
+			return fmt.Errorf("%w: sent %d words, got %d", ErrPunctuatorMismatch, len(chunk), len(words))
+		}
+		counts := make(map[punctuationWord]int)
+		for i, pos := range positions {
+			lines[pos.cue][pos.line][counts[pos]] = words[i]
+			counts[pos]++
+		}
+		chunk, positions = chunk[:0], positions[:0]
+		return nil
+	}
+
+	for i, sub := range subs {
+		if len(chunk) >= punctuationChunkWords || (i > 0 && sub.StartTime-subs[i-1].EndTime > punctuationChunkGap) {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+		for j, words := range lines[i] {
+			for _, word := range words {
+				chunk = append(chunk, word)
+				positions = append(positions, punctuationWord{cue: i, line: j})
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	for i := range subs {
+		text := make([]string, len(lines[i]))
+		for j, words := range lines[i] {
+			text[j] = strings.Join(words, " ")
+		}
+		if joined := strings.Join(text, "\n"); joined != subs[i].Text {
+			subs[i].Text = joined
+			subs[i].Spans = nil
+		}
+	}
+	return c.withSubtitles(subs), nil
+}
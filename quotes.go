@@ -0,0 +1,115 @@
+package caption
+
+import (
+	"strings"
+	"unicode"
+)
+
+type Quote struct {
+	Query      string
+	Text       string
+	Start      float64
+	End        float64
+	Confidence float64
+	URL        string
+}
+
+type quoteWord struct {
+	text string
+	norm string
+	cue  int
+}
+
+func normalizeWord(word string) string {
+	return strings.ToLower(strings.TrimFunc(word, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}))
+}
+
+func quoteWords(text string) []string {
+	var words []string
+	for _, word := range strings.Fields(text) {
+		if norm := normalizeWord(word); norm != "" {
+			words = append(words, norm)
+		}
+	}
+	return words
+}
+
+func wordDistance(a []quoteWord, b []string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1].norm == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func ExtractQuotes(c *Caption, queries []string) []Quote {
+	subtitles := c.GetSubtitleText()
+	var words []quoteWord
+	for i, sub := range subtitles {
+		for _, word := range strings.Fields(sub.Text) {
+			if norm := normalizeWord(word); norm != "" {
+				words = append(words, quoteWord{text: word, norm: norm, cue: i})
+			}
+		}
+	}
+
+	quotes := make([]Quote, 0, len(queries))
+	for _, query := range queries {
+		quote := Quote{Query: query}
+		target := quoteWords(query)
+		if len(target) == 0 || len(words) == 0 {
+			quotes = append(quotes, quote)
+			continue
+		}
+
+		bestStart, bestEnd, bestDistance := -1, -1, len(target)+1
+		for size := max(1, len(target)-len(target)/4); size <= len(target)+len(target)/4; size++ {
+			for start := 0; start+size <= len(words); start++ {
+				if words[start].norm != target[0] && words[start+size-1].norm != target[len(target)-1] && size > 1 {
+					continue
+				}
+				if d := wordDistance(words[start:start+size], target); d < bestDistance {
+					bestStart, bestEnd, bestDistance = start, start+size, d
+				}
+			}
+		}
+		if bestStart < 0 {
+			for start := 0; start+len(target) <= len(words); start++ {
+				if d := wordDistance(words[start:start+len(target)], target); d < bestDistance {
+					bestStart, bestEnd, bestDistance = start, start+len(target), d
+				}
+			}
+		}
+		if bestStart >= 0 && bestDistance < len(target) {
+			window := words[bestStart:bestEnd]
+			text := make([]string, len(window))
+			for i, w := range window {
+				text[i] = w.text
+			}
+			first, last := subtitles[window[0].cue], subtitles[window[len(window)-1].cue]
+			quote.Text = strings.Join(text, " ")
+			quote.Start = first.StartTime
+			quote.End = last.EndTime
+			quote.Confidence = 1 - float64(bestDistance)/float64(len(target))
+			if c.VideoID != "" {
+				quote.URL = first.URL(c.VideoID)
+			}
+		}
+		quotes = append(quotes, quote)
+	}
+	return quotes
+}
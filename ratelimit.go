@@ -0,0 +1,36 @@
+package caption
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func newRateLimiter(rate float64) *rateLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	return &rateLimiter{interval: time.Duration(float64(time.Second) / rate)}
+}
+
+func (l *rateLimiter) wait(ctx context.Context, clock Clock) error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	now := clock.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(l.interval)
+	l.mu.Unlock()
+
+	return sleep(ctx, clock, at.Sub(now))
+}
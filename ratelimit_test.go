@@ -0,0 +1,33 @@
+package caption
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterIsPerClient(t *testing.T) {
+	clock := newFakeClock()
+	opts := &Options{RateLimit: 2, Clock: clock}
+	a, b := NewClient(opts), NewClient(opts)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if err := a.limiter.wait(ctx, clock); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(clock.slept) != 2 || clock.slept[0] != 500*time.Millisecond {
+		t.Fatalf("a slept %v, want two 500ms waits", clock.slept)
+	}
+	clock.slept = nil
+	if err := b.limiter.wait(ctx, clock); err != nil {
+		t.Fatal(err)
+	}
+	if len(clock.slept) != 0 {
+		t.Errorf("b waited %v on a's budget", clock.slept)
+	}
+	if a.With(&Options{RateLimit: 2}).limiter != a.limiter {
+		t.Error("With clone with the same rate got its own budget")
+	}
+}
@@ -0,0 +1,53 @@
+package caption
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var ErrRegionBlocked = errors.New("video is not available in this region")
+
+type RegionBlockedError struct {
+	VideoID          string
+	Region           string
+	AllowedCountries []string
+}
+
+func (e *RegionBlockedError) Error() string {
+	msg := ErrRegionBlocked.Error()
+	if e.Region != "" {
+		msg = fmt.Sprintf("video is not available in region %s", e.Region)
+	}
+	if len(e.AllowedCountries) > 0 && len(e.AllowedCountries) <= 20 {
+		msg += fmt.Sprintf(" (available in: %s)", strings.Join(e.AllowedCountries, ", "))
+	} else if len(e.AllowedCountries) > 20 {
+		msg += fmt.Sprintf(" (available in %d countries)", len(e.AllowedCountries))
+	}
+	return msg
+}
+
+func (e *RegionBlockedError) Is(target error) bool {
+	return target == ErrRegionBlocked
+}
+
+func (p *playerResponse) regionBlocked(region string) *RegionBlockedError {
+	allowed := p.Microformat.PlayerMicroformatRenderer.AvailableCountries
+	blocked := false
+	if status := p.PlayabilityStatus.Status; status != "" && status != "OK" &&
+		strings.Contains(strings.ToLower(p.PlayabilityStatus.Reason), "country") {
+		blocked = true
+	}
+	if region != "" && len(allowed) > 0 && !slices.Contains(allowed, strings.ToUpper(region)) {
+		blocked = true
+	}
+	if !blocked {
+		return nil
+	}
+	return &RegionBlockedError{
+		VideoID:          p.VideoDetails.VideoID,
+		Region:           strings.ToUpper(region),
+		AllowedCountries: allowed,
+	}
+}
@@ -0,0 +1,109 @@
+package caption
+
+import (
+	"fmt"
+	"sort"
+)
+
+type CorrectionKind string
+
+const (
+	CorrectionNegativeStart  CorrectionKind = "negative_start"
+	CorrectionNegativeOffset CorrectionKind = "negative_offset"
+	CorrectionOffsetOrder    CorrectionKind = "offset_order"
+	CorrectionOffsetOverflow CorrectionKind = "offset_overflow"
+	CorrectionEventOrder     CorrectionKind = "event_order"
+)
+
+type Correction struct {
+	Kind     CorrectionKind `json:"kind"`
+	TStartMs int            `json:"tStartMs"`
+	Segment  int            `json:"segment"`
+	From     int            `json:"from"`
+	To       int            `json:"to"`
+}
+
+func (c Correction) String() string {
+	if c.Segment < 0 {
+		return fmt.Sprintf("%s at %dms: %d -> %d", c.Kind, c.TStartMs, c.From, c.To)
+	}
+	return fmt.Sprintf("%s at %dms segment %d: %d -> %d", c.Kind, c.TStartMs, c.Segment, c.From, c.To)
+}
+
+func (c *Caption) Sanitize() (*Caption, []Correction) {
+	events := make([]CaptionEvent, len(c.Events))
+	for i, event := range c.Events {
+		event.Segments = append([]CaptionSegment(nil), event.Segments...)
+		events[i] = event
+	}
+	corrections := sanitizeEvents(events)
+
+	result := *c
+	result.Events = events
+	result.Corrections = append(append([]Correction(nil), c.Corrections...), corrections...)
+	return &result, corrections
+}
+
+func sanitizeEvents(events []CaptionEvent) []Correction {
+	var corrections []Correction
+
+	for i := range events {
+		if events[i].TStartMs < 0 {
+			corrections = append(corrections, Correction{
+				Kind: CorrectionNegativeStart, TStartMs: events[i].TStartMs, Segment: -1, From: events[i].TStartMs,
+			})
+			events[i].TStartMs = 0
+		}
+	}
+
+	if !sort.SliceIsSorted(events, func(i, j int) bool { return events[i].TStartMs < events[j].TStartMs }) {
+		order := make([]int, len(events))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(i, j int) bool { return events[order[i]].TStartMs < events[order[j]].TStartMs })
+		sorted := make([]CaptionEvent, len(events))
+		for to, from := range order {
+			sorted[to] = events[from]
+			if to != from {
+				corrections = append(corrections, Correction{
+					Kind: CorrectionEventOrder, TStartMs: events[from].TStartMs, Segment: -1, From: from, To: to,
+				})
+			}
+		}
+		copy(events, sorted)
+	}
+
+	for i := range events {
+		event := &events[i]
+		limit := -1
+		for j := i + 1; j < len(events); j++ {
+			if events[j].TStartMs > event.TStartMs {
+				limit = events[j].TStartMs - event.TStartMs
+				break
+			}
+		}
+
+		previous := 0
+		for j := range event.Segments {
+			seg := &event.Segments[j]
+			fix := func(kind CorrectionKind, to int) {
+				corrections = append(corrections, Correction{
+					Kind: kind, TStartMs: event.TStartMs, Segment: j, From: seg.TOffsetMs, To: to,
+				})
+				seg.TOffsetMs = to
+			}
+			switch {
+			case seg.TOffsetMs < 0:
+				fix(CorrectionNegativeOffset, 0)
+			case limit >= 0 && seg.TOffsetMs > limit:
+				fix(CorrectionOffsetOverflow, limit)
+			}
+			if seg.TOffsetMs < previous {
+				fix(CorrectionOffsetOrder, previous)
+			}
+			previous = seg.TOffsetMs
+		}
+	}
+	return corrections
+}
@@ -0,0 +1,176 @@
+package caption
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+const defaultFileMode os.FileMode = 0644
+
+type SaveOptions struct {
+	Atomic   bool
+	Mode     os.FileMode
+	Manifest bool
+	Clock    Clock
+}
+
+func writeFileWith(filename string, opts *SaveOptions, write func(io.Writer) error) error {
+	if opts == nil {
+		opts = &SaveOptions{}
+	}
+	mode := opts.Mode
+	if mode == 0 {
+		mode = defaultFileMode
+	}
+
+	if !opts.Atomic {
+		f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+		if err != nil {
+			return err
+		}
+		if err = write(f); err != nil {
+			_ = f.Close()
+			return err
+		}
+		return f.Close()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(filename), "."+filepath.Base(filename)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpName := tmp.Name()
+	defer func() {
+		if err != nil {
+			_ = os.Remove(tmpName)
+		}
+	}()
+
+	if err = write(tmp); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = tmp.Chmod(mode); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmpName, filename); err != nil {
+		return fmt.Errorf("failed to rename temp file: %w", err)
+	}
+	return nil
+}
+
+func (c *Caption) SaveWithOptions(filename string, format Format, opts *SaveOptions) error {
+	return c.SaveWithContext(context.Background(), filename, format, opts)
+}
+
+func (c *Caption) SaveWithContext(ctx context.Context, filename string, format Format, opts *SaveOptions) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	h := sha256.New()
+	counter := &countingWriter{w: h}
+	err := writeFileWith(filename, opts, func(w io.Writer) error {
+		return c.Write(&contextWriter{ctx: ctx, w: io.MultiWriter(w, counter)}, format)
+	})
+	if err != nil || opts == nil || !opts.Manifest {
+		return err
+	}
+	clock := opts.Clock
+	if clock == nil {
+		clock = c.clock()
+	}
+	entry := c.manifestEntry(filename, format, h.Sum(nil), counter.n, clock.Now())
+	if err = UpdateManifest(filepath.Dir(filename), entry); err != nil {
+		return fmt.Errorf("failed to update manifest: %w", err)
+	}
+	return nil
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+const contextWriteChunk = 32 << 10
+
+type contextWriter struct {
+	ctx context.Context
+	w   io.Writer
+}
+
+func (cw *contextWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		if err := cw.ctx.Err(); err != nil {
+			return written, err
+		}
+		chunk := p[:min(len(p), contextWriteChunk)]
+		n, err := cw.w.Write(chunk)
+		written += n
+		if err != nil {
+			return written, err
+		}
+		p = p[n:]
+	}
+	return written, nil
+}
+
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (cr *contextReader) Read(p []byte) (int, error) {
+	if err := cr.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cr.r.Read(p)
+}
+
+func (c *Caption) SaveSRTContext(ctx context.Context, filename string) error {
+	return c.SaveWithContext(ctx, filename, FormatSRT, nil)
+}
+
+func (c *Caption) SaveVTTContext(ctx context.Context, filename string) error {
+	return c.SaveWithContext(ctx, filename, FormatVTT, nil)
+}
+
+func (c *Caption) SavePlainTextContext(ctx context.Context, filename string) error {
+	return c.SaveWithContext(ctx, filename, FormatText, nil)
+}
+
+func (c *Caption) SaveAll(dir string, formats ...Format) error {
+	return c.SaveAllContext(context.Background(), dir, formats...)
+}
+
+func (c *Caption) SaveAllContext(ctx context.Context, dir string, formats ...Format) error {
+	name := c.VideoID
+	if name == "" {
+		name = "captions"
+	}
+	for _, format := range formats {
+		filename := filepath.Join(dir, name+format.Ext())
+		if err := c.SaveWithContext(ctx, filename, format, &SaveOptions{Atomic: true}); err != nil {
+			return fmt.Errorf("failed to save %s: %w", filename, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package caption
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+)
+
+const ExportSchemaVersion = 1
+
+type ExportCue struct {
+	ID    string  `json:"id,omitempty"`
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+	Text  string  `json:"text"`
+}
+
+type ExportDocument struct {
+	Version  int         `json:"version"`
+	VideoID  string      `json:"videoId,omitempty"`
+	Language string      `json:"language,omitempty"`
+	Kind     string      `json:"kind,omitempty"`
+	Cues     []ExportCue `json:"cues"`
+}
+
+func (d ExportDocument) MarshalJSON() ([]byte, error) {
+	type document ExportDocument
+	cues := make([]ExportCue, len(d.Cues))
+	for i, cue := range d.Cues {
+		cue.Start, cue.End = roundMillis(cue.Start), roundMillis(cue.End)
+		cues[i] = cue
+	}
+	if d.Version == 0 {
+		d.Version = ExportSchemaVersion
+	}
+	d.Cues = cues
+	return json.Marshal(document(d))
+}
+
+func roundMillis(seconds float64) float64 {
+	return math.Round(seconds*1000) / 1000
+}
+
+func (c *Caption) Export() *ExportDocument {
+	doc := &ExportDocument{
+		Version: ExportSchemaVersion,
+		VideoID: c.VideoID,
+		Cues:    []ExportCue{},
+	}
+	if c.Track != nil {
+		doc.Language = c.Track.LanguageCode
+		doc.Kind = c.Track.Kind
+	}
+	for _, sub := range c.GetSubtitleText() {
+		doc.Cues = append(doc.Cues, ExportCue{ID: sub.ID(c.VideoID), Start: sub.StartTime, End: sub.EndTime, Text: sub.Text})
+	}
+	return doc
+}
+
+func (d *ExportDocument) Caption() *Caption {
+	subs := make([]SubtitleText, len(d.Cues))
+	for i, cue := range d.Cues {
+		subs[i] = SubtitleText{StartTime: cue.Start, EndTime: cue.End, Text: cue.Text}
+	}
+	c := (&Caption{VideoID: d.VideoID}).withSubtitles(subs)
+	if d.Language != "" || d.Kind != "" {
+		c.Track = &CaptionTrack{LanguageCode: d.Language, Kind: d.Kind}
+	}
+	return c
+}
+
+func ParseExport(data []byte) (*Caption, error) {
+	var doc ExportDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal export: %w", err)
+	}
+	if doc.Version < 1 || doc.Version > ExportSchemaVersion {
+		return nil, fmt.Errorf("unsupported export schema version %d", doc.Version)
+	}
+	return doc.Caption(), nil
+}
+
+func (c *Caption) SaveExport(filename string) error {
+	data, err := json.MarshalIndent(c.Export(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal export: %w", err)
+	}
+	return os.WriteFile(filename, data, 0644)
+}
+
+func LoadExport(filename string) (*Caption, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return ParseExport(data)
+}
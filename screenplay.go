@@ -0,0 +1,68 @@
+package caption
+
+import (
+	"os"
+	"strings"
+)
+
+const screenplayParagraphGap = 2.0
+
+type screenplayBlock struct {
+	speaker string
+	text    []string
+}
+
+func (c *Caption) screenplayBlocks() []screenplayBlock {
+	var blocks []screenplayBlock
+	var lastEnd float64
+	for _, sub := range c.GetSubtitleText() {
+		paused := len(blocks) > 0 && sub.StartTime-lastEnd >= screenplayParagraphGap
+		lastEnd = sub.EndTime
+		for _, line := range strings.Split(sub.Text, "\n") {
+			speaker, line, turn := splitSpeaker(strings.TrimSpace(line))
+			line = soundCueRegex.ReplaceAllString(line, "")
+			line = strings.TrimSpace(spaceRunRegex.ReplaceAllString(line, " "))
+			if line == "" && speaker == "" {
+				continue
+			}
+			n := len(blocks)
+			switch {
+			case n == 0, turn && (speaker == "" || speaker != blocks[n-1].speaker):
+				blocks = append(blocks, screenplayBlock{speaker: speaker})
+			case paused && !turn:
+				blocks = append(blocks, screenplayBlock{speaker: blocks[n-1].speaker})
+			}
+			paused = false
+			if line != "" {
+				block := &blocks[len(blocks)-1]
+				block.text = append(block.text, line)
+			}
+		}
+	}
+	return blocks
+}
+
+func (c *Caption) GetScreenplay() string {
+	var result strings.Builder
+	result.WriteString(strings.ToUpper(c.title()))
+	result.WriteString("\n\n")
+
+	var previous string
+	for _, block := range c.screenplayBlocks() {
+		if len(block.text) == 0 {
+			continue
+		}
+		if block.speaker != "" && block.speaker != previous {
+			result.WriteString(strings.ToUpper(block.speaker))
+			result.WriteString("\n")
+		}
+		previous = block.speaker
+		result.WriteString(strings.Join(block.text, " "))
+		result.WriteString("\n\n")
+	}
+	return result.String()
+}
+
+func (c *Caption) SaveScreenplay(filename string) error {
+	return os.WriteFile(filename, []byte(c.GetScreenplay()), 0644)
+}
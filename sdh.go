@@ -0,0 +1,57 @@
+package caption
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	soundCueRegex      = regexp.MustCompile(`\[[^\]]*\]|\([^)]*\)|♪+`)
+	speakerLabelRegex  = regexp.MustCompile(`^(?:>>\s*)?(\p{Lu}[\p{Lu}\p{N} .'-]+):\s*`)
+	speakerChangeRegex = regexp.MustCompile(`^(?:>>|-)\s*`)
+	spaceRunRegex      = regexp.MustCompile(`[ \t]{2,}`)
+)
+
+func splitSpeaker(line string) (string, string, bool) {
+	if m := speakerLabelRegex.FindStringSubmatch(line); m != nil {
+		return strings.TrimSpace(m[1]), line[len(m[0]):], true
+	}
+	if m := speakerChangeRegex.FindString(line); m != "" {
+		return "", line[len(m):], true
+	}
+	return "", line, false
+}
+
+func stripSDH(text string) string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		_, line, _ = splitSpeaker(strings.TrimSpace(line))
+		line = soundCueRegex.ReplaceAllString(line, "")
+		line = strings.TrimSpace(spaceRunRegex.ReplaceAllString(line, " "))
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (c *Caption) SDH(enabled bool) *Caption {
+	subs := c.GetSubtitleText()
+	if enabled {
+		return c.withSubtitles(subs)
+	}
+
+	result := make([]SubtitleText, 0, len(subs))
+	for _, sub := range subs {
+		text := stripSDH(sub.Text)
+		if text == "" {
+			continue
+		}
+		if text != sub.Text {
+			sub.Text = text
+			sub.Spans = nil
+		}
+		result = append(result, sub)
+	}
+	return c.withSubtitles(result)
+}
@@ -0,0 +1,98 @@
+package caption
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+type SearchMatch struct {
+	VideoID string
+	Index   int
+	ID      string
+	Cue     SubtitleText
+}
+
+func LoadFromFile(filename string) (*Caption, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return parseJSON(data)
+}
+
+func parseJSON(data []byte) (*Caption, error) {
+	var probe struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &probe); err == nil && probe.Version > 0 {
+		return ParseExport(data)
+	}
+	var caption Caption
+	if err := json.Unmarshal(data, &caption); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal caption: %w", err)
+	}
+	return &caption, nil
+}
+
+func (c *Caption) Search(query string) []SearchMatch {
+	idx := NewIndex()
+	idx.Add(c.VideoID, c)
+	return idx.Search(query)
+}
+
+type indexedCue struct {
+	videoID string
+	index   int
+	id      string
+	cue     SubtitleText
+	lower   string
+}
+
+type Index struct {
+	cues []indexedCue
+}
+
+func NewIndex() *Index {
+	return &Index{}
+}
+
+func (idx *Index) Add(videoID string, c *Caption) {
+	for i, cue := range c.GetSubtitleText() {
+		idx.cues = append(idx.cues, indexedCue{
+			videoID: videoID,
+			index:   i,
+			id:      cue.ID(videoID),
+			cue:     cue,
+			lower:   strings.ToLower(cue.Text),
+		})
+	}
+}
+
+func (idx *Index) Len() int {
+	return len(idx.cues)
+}
+
+func (idx *Index) Search(query string) []SearchMatch {
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return nil
+	}
+	phrase := strings.Join(terms, " ")
+
+	var matches []SearchMatch
+	for _, ic := range idx.cues {
+		if strings.Contains(ic.lower, phrase) {
+			matches = append(matches, SearchMatch{VideoID: ic.videoID, Index: ic.index, ID: ic.id, Cue: ic.cue})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].VideoID != matches[j].VideoID {
+			return matches[i].VideoID < matches[j].VideoID
+		}
+		return matches[i].Cue.StartTime < matches[j].Cue.StartTime
+	})
+	return matches
+}
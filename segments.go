@@ -0,0 +1,66 @@
+package caption
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type SkipSegment struct {
+	Start    time.Duration
+	End      time.Duration
+	Category string
+}
+
+type SegmentProvider interface {
+	Segments(ctx context.Context, videoID string) ([]SkipSegment, error)
+}
+
+func cueSegment(sub SubtitleText, segments []SkipSegment) (SkipSegment, bool) {
+	mid := secondsToDuration((sub.StartTime + sub.EndTime) / 2)
+	for _, seg := range segments {
+		if mid >= seg.Start && mid < seg.End {
+			return seg, true
+		}
+	}
+	return SkipSegment{}, false
+}
+
+func (c *Caption) RemoveSegments(segments []SkipSegment) *Caption {
+	var result []SubtitleText
+	for _, sub := range c.GetSubtitleText() {
+		if _, ok := cueSegment(sub, segments); !ok {
+			result = append(result, sub)
+		}
+	}
+	return c.withSubtitles(result)
+}
+
+func (c *Caption) LabelSegments(segments []SkipSegment) *Caption {
+	subs := c.GetSubtitleText()
+	for i, sub := range subs {
+		seg, ok := cueSegment(sub, segments)
+		if !ok {
+			continue
+		}
+		label := seg.Category
+		if label == "" {
+			label = "skip"
+		}
+		subs[i].Text = fmt.Sprintf("[%s] %s", strings.ToUpper(label[:1])+label[1:], sub.Text)
+		subs[i].Spans = nil
+	}
+	return c.withSubtitles(subs)
+}
+
+func (c *Caption) FilterSegments(ctx context.Context, provider SegmentProvider, label bool) (*Caption, error) {
+	segments, err := provider.Segments(ctx, c.VideoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch skip segments: %w", err)
+	}
+	if label {
+		return c.LabelSegments(segments), nil
+	}
+	return c.RemoveSegments(segments), nil
+}
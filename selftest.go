@@ -0,0 +1,115 @@
+package caption
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const SelfTestVideoID = "vStJoetOxJg"
+
+var ErrSelfTestFailed = errors.New("self test failed")
+
+type ProbeResult struct {
+	Client  InnerTubeClient
+	Latency time.Duration
+	Tracks  int
+	Err     error
+}
+
+func (r ProbeResult) OK() bool {
+	return r.Err == nil && r.Tracks > 0
+}
+
+type SelfTestReport struct {
+	VideoID string
+	Config  []error
+	Probes  []ProbeResult
+}
+
+func (r *SelfTestReport) OK() bool {
+	return r.Err() == nil
+}
+
+func (r *SelfTestReport) Err() error {
+	if len(r.Config) > 0 {
+		return fmt.Errorf("%w: %w", ErrSelfTestFailed, errors.Join(r.Config...))
+	}
+	var errs []error
+	for _, probe := range r.Probes {
+		if probe.OK() {
+			return nil
+		}
+		err := probe.Err
+		if err == nil {
+			err = &NoCaptionsError{VideoID: r.VideoID}
+		}
+		errs = append(errs, fmt.Errorf("%s %s: %w", probe.Client.Name, probe.Client.Version, err))
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %w", ErrSelfTestFailed, errors.Join(errs...))
+}
+
+func SelfTest(ctx context.Context) *SelfTestReport {
+	return NewClient(nil).SelfTest(ctx)
+}
+
+func SelfTestWithOptions(ctx context.Context, opts *Options) *SelfTestReport {
+	return NewClient(opts).SelfTest(ctx)
+}
+
+func (c *Client) SelfTest(ctx context.Context) *SelfTestReport {
+	report := &SelfTestReport{VideoID: SelfTestVideoID, Config: c.CheckConfig()}
+	if len(report.Config) > 0 {
+		return report
+	}
+	clock := c.opts.clock()
+	for _, client := range c.opts.innerTubeClients() {
+		start := clock.Now()
+		probe := ProbeResult{Client: c.clientVersion(ctx, client)}
+		playerResp, err := c.requestPlayerAs(ctx, report.VideoID, client)
+		if err == nil {
+			var tracks []CaptionTrack
+			tracks, err = extractCaptionTracks(playerResp, c.opts.Region)
+			probe.Tracks = len(tracks)
+		}
+		probe.Latency, probe.Err = clock.Now().Sub(start), err
+		report.Probes = append(report.Probes, probe)
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return report
+}
+
+func (c *Client) CheckConfig() []error {
+	opts := c.opts
+	var errs []error
+	for i, client := range opts.Clients {
+		if client.Name == "" || client.Version == "" {
+			errs = append(errs, fmt.Errorf("client %d: name and version are required", i))
+		}
+	}
+	for _, client := range opts.innerTubeClients() {
+		if opts.userAgentFor(client) == "" {
+			errs = append(errs, fmt.Errorf("client %s: no user agent", client.Name))
+		}
+	}
+	if opts.Proxy != "" {
+		if u, err := url.Parse(opts.Proxy); err != nil || u.Scheme == "" || u.Host == "" {
+			errs = append(errs, fmt.Errorf("invalid proxy URL %q", opts.Proxy))
+		}
+	}
+	if opts.Region != "" && (len(opts.Region) != 2 || strings.Trim(strings.ToUpper(opts.Region), "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "") {
+		errs = append(errs, fmt.Errorf("invalid region %q, expected a two-letter code such as DE", opts.Region))
+	}
+	if opts.Language == "" && len(opts.Languages) == 0 {
+		errs = append(errs, errors.New("no caption language configured"))
+	}
+	return errs
+}
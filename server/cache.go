@@ -0,0 +1,62 @@
+package server
+
+import (
+	"sync"
+	"time"
+
+	caption "github.com/lincaiyong/youtube-caption"
+)
+
+type cacheEntry struct {
+	value     any
+	expiresAt time.Time
+}
+
+type cache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	clock   caption.Clock
+	entries map[string]cacheEntry
+}
+
+func newCache(ttl time.Duration, clock caption.Clock) *cache {
+	return &cache{ttl: ttl, clock: clock, entries: make(map[string]cacheEntry)}
+}
+
+func (c *cache) get(key string) (any, bool) {
+	if c.ttl <= 0 {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if c.clock.Now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+func (c *cache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+}
+
+func (c *cache) set(key string, value any) {
+	if c.ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.clock.Now()
+	for k, entry := range c.entries {
+		if now.After(entry.expiresAt) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = cacheEntry{value: value, expiresAt: now.Add(c.ttl)}
+}
@@ -0,0 +1,20 @@
+package server
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCacheExpiresWithClock(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	c := newCache(time.Minute, clock)
+	c.set("a", 1)
+	clock.Advance(time.Minute)
+	if v, ok := c.get("a"); !ok || v != 1 {
+		t.Fatalf("get at the TTL = %v, %v; want 1, true", v, ok)
+	}
+	clock.Advance(time.Nanosecond)
+	if _, ok := c.get("a"); ok {
+		t.Fatal("entry still cached after its TTL")
+	}
+}
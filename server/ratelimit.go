@@ -0,0 +1,78 @@
+package server
+
+import (
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	caption "github.com/lincaiyong/youtube-caption"
+)
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+type rateLimiter struct {
+	mu         sync.Mutex
+	rate       float64
+	burst      float64
+	trustProxy bool
+	clock      caption.Clock
+	buckets    map[string]*bucket
+}
+
+func newRateLimiter(rate float64, burst int, trustProxy bool, clock caption.Clock) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{rate: rate, burst: float64(burst), trustProxy: trustProxy, clock: clock, buckets: make(map[string]*bucket)}
+}
+
+func (l *rateLimiter) allow(r *http.Request) bool {
+	if l.rate <= 0 {
+		return true
+	}
+	key := l.clientIP(r)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.clock.Now()
+	b, ok := l.buckets[key]
+	if !ok {
+		l.evict(now)
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+func (l *rateLimiter) evict(now time.Time) {
+	refill := time.Duration(l.burst / l.rate * float64(time.Second))
+	for key, b := range l.buckets {
+		if now.Sub(b.last) >= refill {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+func (l *rateLimiter) clientIP(r *http.Request) string {
+	if l.trustProxy {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			first, _, _ := strings.Cut(forwarded, ",")
+			return strings.TrimSpace(first)
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	caption "github.com/lincaiyong/youtube-caption"
+)
+
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.Advance(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.Now()
+	return ch
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestRateLimiterPerClientIP(t *testing.T) {
+	l := newRateLimiter(1, 2, false, caption.SystemClock)
+	a := httptest.NewRequest("GET", "/", nil)
+	a.RemoteAddr = "10.0.0.1:1234"
+	b := httptest.NewRequest("GET", "/", nil)
+	b.RemoteAddr = "10.0.0.2:1234"
+
+	for i := 0; i < 2; i++ {
+		if !l.allow(a) {
+			t.Fatalf("request %d from a denied within burst", i)
+		}
+	}
+	if l.allow(a) {
+		t.Fatal("request from a allowed after burst exhausted")
+	}
+	if !l.allow(b) {
+		t.Fatal("request from b denied by a's budget")
+	}
+}
+
+func TestRateLimiterTrustProxy(t *testing.T) {
+	l := newRateLimiter(1, 1, true, caption.SystemClock)
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.1")
+	if got := l.clientIP(r); got != "203.0.113.7" {
+		t.Fatalf("clientIP = %q, want 203.0.113.7", got)
+	}
+	l.trustProxy = false
+	r.RemoteAddr = "10.0.0.1:80"
+	if got := l.clientIP(r); got != "10.0.0.1" {
+		t.Fatalf("clientIP = %q, want 10.0.0.1", got)
+	}
+}
+
+func TestRateLimiterRefillsWithClock(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	l := newRateLimiter(2, 1, false, clock)
+	r := httptest.NewRequest("GET", "/", nil)
+	if !l.allow(r) || l.allow(r) {
+		t.Fatal("want one request allowed, then denied")
+	}
+	clock.Advance(400 * time.Millisecond)
+	if l.allow(r) {
+		t.Fatal("allowed before a token refilled")
+	}
+	clock.Advance(100 * time.Millisecond)
+	if !l.allow(r) {
+		t.Fatal("denied after a token refilled")
+	}
+	clock.Advance(time.Hour)
+	l.evict(clock.Now())
+	if len(l.buckets) != 0 {
+		t.Errorf("%d idle buckets left after eviction", len(l.buckets))
+	}
+}
@@ -0,0 +1,281 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	caption "github.com/lincaiyong/youtube-caption"
+)
+
+type Config struct {
+	Options    *caption.Options
+	CacheTTL   time.Duration
+	RateLimit  float64
+	Burst      int
+	TrustProxy bool
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Options:   caption.DefaultOptions(),
+		CacheTTL:  10 * time.Minute,
+		RateLimit: 5,
+		Burst:     10,
+	}
+}
+
+type Server struct {
+	client  *caption.Client
+	opts    *caption.Options
+	cache   *cache
+	limiter *rateLimiter
+	mux     *http.ServeMux
+}
+
+func New(cfg Config) *Server {
+	if cfg.Options == nil {
+		cfg.Options = caption.DefaultOptions()
+	}
+	clock := cfg.Options.Clock
+	if clock == nil {
+		clock = caption.SystemClock
+	}
+	s := &Server{
+		client:  caption.NewClient(cfg.Options),
+		opts:    cfg.Options,
+		cache:   newCache(cfg.CacheTTL, clock),
+		limiter: newRateLimiter(cfg.RateLimit, cfg.Burst, cfg.TrustProxy, clock),
+		mux:     http.NewServeMux(),
+	}
+	s.mux.HandleFunc("GET /videos/{id}/tracks", s.handleTracks)
+	s.mux.HandleFunc("GET /videos/{id}/captions", s.handleCaptions)
+	s.mux.HandleFunc("GET /videos/{id}/captions/stream", s.handleStream)
+	s.mux.HandleFunc("GET /readyz", s.handleReady)
+	s.mux.HandleFunc("GET /usage", s.handleUsage)
+	return s
+}
+
+func (s *Server) Shutdown(ctx context.Context) error {
+	err := s.client.Shutdown(ctx)
+	s.cache.clear()
+	return err
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.limiter.allow(r) {
+		writeError(w, http.StatusTooManyRequests, errors.New("rate limit exceeded"))
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleTracks(w http.ResponseWriter, r *http.Request) {
+	videoID := r.PathValue("id")
+	key := "tracks:" + videoID
+	if cached, ok := s.cache.get(key); ok {
+		writeJSON(w, cached)
+		return
+	}
+
+	tracks, err := s.client.GetAvailableTracks(r.Context(), videoID)
+	if err != nil {
+		writeError(w, statusForError(err), err)
+		return
+	}
+	s.cache.set(key, tracks)
+	writeJSON(w, tracks)
+}
+
+func (s *Server) handleCaptions(w http.ResponseWriter, r *http.Request) {
+	videoID := r.PathValue("id")
+	query := r.URL.Query()
+
+	format := caption.FormatJSON
+	if f := query.Get("format"); f != "" {
+		var err error
+		if format, err = caption.ParseFormat(f); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	opts := *s.opts
+	if lang := query.Get("lang"); lang != "" {
+		opts.Language = lang
+	}
+	if kind, ok := query["kind"]; ok {
+		opts.Kind = kind[0]
+	}
+
+	key := "captions:" + videoID + ":" + opts.Language + ":" + opts.Kind
+	var c *caption.Caption
+	if cached, ok := s.cache.get(key); ok {
+		c = cached.(*caption.Caption)
+	} else {
+		var err error
+		c, err = s.client.With(&opts).Download(r.Context(), videoID)
+		if err != nil {
+			writeError(w, statusForError(err), err)
+			return
+		}
+		s.cache.set(key, c)
+	}
+
+	var buf bytes.Buffer
+	if err := c.Write(&buf, format); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", contentType(format))
+	_, _ = buf.WriteTo(w)
+}
+
+func (s *Server) handleStream(w http.ResponseWriter, r *http.Request) {
+	videoID := r.PathValue("id")
+	query := r.URL.Query()
+
+	format := caption.StreamNDJSON
+	if query.Get("format") == string(caption.StreamSSE) || r.Header.Get("Accept") == "text/event-stream" {
+		format = caption.StreamSSE
+	}
+
+	opts := *s.opts
+	if lang := query.Get("lang"); lang != "" {
+		opts.Language = lang
+	}
+	if kind, ok := query["kind"]; ok {
+		opts.Kind = kind[0]
+	}
+
+	sw := caption.NewStreamWriter(w, format)
+	started := false
+	err := s.client.With(&opts).DownloadStream(r.Context(), videoID, func(cue caption.SubtitleText) error {
+		if !started {
+			w.Header().Set("Content-Type", format.ContentType())
+			w.Header().Set("Cache-Control", "no-cache")
+			started = true
+		}
+		return sw.WriteCue(cue)
+	})
+	if err != nil {
+		if !started {
+			writeError(w, statusForError(err), err)
+		}
+		return
+	}
+	if !started {
+		w.Header().Set("Content-Type", format.ContentType())
+	}
+	_ = sw.Close()
+}
+
+func contentType(format caption.Format) string {
+	switch format {
+	case caption.FormatJSON, caption.FormatCues, caption.FormatChapters:
+		return "application/json"
+	case caption.FormatChunks:
+		return "application/x-ndjson"
+	case caption.FormatSRT:
+		return "application/x-subrip; charset=utf-8"
+	case caption.FormatVTT:
+		return "text/vtt; charset=utf-8"
+	case caption.FormatMarkdown:
+		return "text/markdown; charset=utf-8"
+	case caption.FormatHTML:
+		return "text/html; charset=utf-8"
+	case caption.FormatXLIFF, caption.FormatTMX:
+		return "application/xml; charset=utf-8"
+	case caption.FormatTTML:
+		return "application/ttml+xml; charset=utf-8"
+	case caption.FormatASS:
+		return "text/x-ssa; charset=utf-8"
+	case caption.FormatBundle:
+		return "application/zip"
+	default:
+		return "text/plain; charset=utf-8"
+	}
+}
+
+func (s *Server) handleUsage(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, s.client.Snapshot())
+}
+
+type probeStatus struct {
+	Client    string `json:"client"`
+	Version   string `json:"version"`
+	LatencyMs int64  `json:"latencyMs"`
+	Tracks    int    `json:"tracks"`
+	Error     string `json:"error,omitempty"`
+}
+
+func (s *Server) handleReady(w http.ResponseWriter, r *http.Request) {
+	if s.client.Closed() {
+		writeError(w, http.StatusServiceUnavailable, caption.ErrClientClosed)
+		return
+	}
+	var report *caption.SelfTestReport
+	if cached, ok := s.cache.get("selftest"); ok {
+		report = cached.(*caption.SelfTestReport)
+	} else {
+		report = s.client.SelfTest(r.Context())
+		if report.OK() {
+			s.cache.set("selftest", report)
+		}
+	}
+
+	probes := make([]probeStatus, len(report.Probes))
+	for i, probe := range report.Probes {
+		probes[i] = probeStatus{
+			Client:    probe.Client.Name,
+			Version:   probe.Client.Version,
+			LatencyMs: probe.Latency.Milliseconds(),
+			Tracks:    probe.Tracks,
+		}
+		if probe.Err != nil {
+			probes[i].Error = probe.Err.Error()
+		}
+	}
+	body := map[string]any{"ok": report.OK(), "probes": probes}
+	if err := report.Err(); err != nil {
+		body["error"] = err.Error()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_ = json.NewEncoder(w).Encode(body)
+		return
+	}
+	writeJSON(w, body)
+}
+
+func statusForError(err error) int {
+	switch {
+	case errors.Is(err, caption.ErrInvalidVideoID):
+		return http.StatusBadRequest
+	case errors.Is(err, caption.ErrNoCaptionsFound):
+		return http.StatusNotFound
+	case errors.Is(err, caption.ErrLiveInProgress):
+		return http.StatusConflict
+	case errors.Is(err, caption.ErrNotYetAvailable):
+		return http.StatusTooEarly
+	case errors.Is(err, caption.ErrRegionBlocked):
+		return http.StatusUnavailableForLegalReasons
+	case errors.Is(err, caption.ErrRateLimited), errors.Is(err, caption.ErrClientClosed):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusBadGateway
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
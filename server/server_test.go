@@ -0,0 +1,57 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	caption "github.com/lincaiyong/youtube-caption"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+const playerResponse = `{"playabilityStatus":{"status":"OK"},"captions":{"playerCaptionsTracklistRenderer":{"captionTracks":[` +
+	`{"baseUrl":"https://www.youtube.com/api/timedtext?v=vStJoetOxJg&lang=en","languageCode":"en","kind":"asr"}]}}}`
+
+func TestReadyCachesOnlyPassingSelfTests(t *testing.T) {
+	var healthy atomic.Bool
+	var requests atomic.Int32
+	opts := caption.DefaultOptions()
+	opts.Transport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requests.Add(1)
+		status, body := http.StatusForbidden, "{}"
+		if healthy.Load() {
+			status, body = http.StatusOK, playerResponse
+		}
+		return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(body)), Header: http.Header{}, Request: req}, nil
+	})
+	s := New(Config{Options: opts, CacheTTL: time.Minute})
+
+	ready := func() int {
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, httptest.NewRequest("GET", "/readyz", nil))
+		return rec.Code
+	}
+	if code := ready(); code != http.StatusServiceUnavailable {
+		t.Fatalf("failing self test: status %d, want 503", code)
+	}
+	healthy.Store(true)
+	if code := ready(); code != http.StatusOK {
+		t.Fatalf("recovered self test: status %d, want 200 (failing report was cached)", code)
+	}
+	before := requests.Load()
+	if code := ready(); code != http.StatusOK {
+		t.Fatalf("cached self test: status %d, want 200", code)
+	}
+	if requests.Load() != before {
+		t.Error("passing self test was not cached")
+	}
+}
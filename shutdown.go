@@ -0,0 +1,99 @@
+package caption
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+)
+
+var ErrClientClosed = errors.New("client is shut down")
+
+type admittedKey struct{}
+
+type lifecycle struct {
+	mu      sync.Mutex
+	closed  bool
+	ops     sync.WaitGroup
+	stop    chan struct{}
+	drained chan struct{}
+	once    sync.Once
+
+	abort       context.Context
+	cancelAbort context.CancelFunc
+}
+
+func newLifecycle() *lifecycle {
+	abort, cancel := context.WithCancel(context.Background())
+	return &lifecycle{stop: make(chan struct{}), drained: make(chan struct{}), abort: abort, cancelAbort: cancel}
+}
+
+func (c *Client) begin(ctx context.Context) (context.Context, func(), error) {
+	l := c.life
+	if ctx.Value(admittedKey{}) == l {
+		return ctx, func() {}, nil
+	}
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return ctx, nil, ErrClientClosed
+	}
+	l.ops.Add(1)
+	l.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(context.WithValue(ctx, admittedKey{}, l))
+	stopAbort := context.AfterFunc(l.abort, cancel)
+	return ctx, func() {
+		stopAbort()
+		cancel()
+		l.ops.Done()
+	}, nil
+}
+
+type endBody struct {
+	io.ReadCloser
+	end func()
+}
+
+func (b *endBody) Close() error {
+	defer b.end()
+	return b.ReadCloser.Close()
+}
+
+func (c *Client) Closed() bool {
+	c.life.mu.Lock()
+	defer c.life.mu.Unlock()
+	return c.life.closed
+}
+
+func (c *Client) Shutdown(ctx context.Context) error {
+	l := c.life
+	l.mu.Lock()
+	if !l.closed {
+		l.closed = true
+		close(l.stop)
+	}
+	l.mu.Unlock()
+	l.once.Do(func() {
+		go func() {
+			l.ops.Wait()
+			close(l.drained)
+		}()
+	})
+
+	var err error
+	select {
+	case <-l.drained:
+	case <-ctx.Done():
+		err = ctx.Err()
+		l.cancelAbort()
+		<-l.drained
+	}
+	c.players.clear()
+	c.httpClient.CloseIdleConnections()
+	return err
+}
+
+func (c *Client) Close() error {
+	return c.Shutdown(context.Background())
+}
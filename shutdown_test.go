@@ -0,0 +1,47 @@
+package caption
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAdmissionIsPerClient(t *testing.T) {
+	a := NewClient(nil)
+	ctx, endA, err := a.begin(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer endA()
+
+	closed := NewClient(nil)
+	if err = closed.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = closed.begin(ctx); !errors.Is(err, ErrClientClosed) {
+		t.Errorf("closed client admitted a context from another client: %v", err)
+	}
+
+	b := NewClient(nil)
+	_, endB, err := b.begin(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shutdown := make(chan error, 1)
+	go func() { shutdown <- b.Shutdown(context.Background()) }()
+	select {
+	case err = <-shutdown:
+		t.Fatalf("Shutdown returned %v with an operation in flight", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	endB()
+	select {
+	case err = <-shutdown:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Shutdown did not return after the operation ended")
+	}
+}
@@ -0,0 +1,32 @@
+package caption
+
+import "context"
+
+type CueSink interface {
+	Send(ctx context.Context, videoID string, cue SubtitleText) error
+}
+
+type CueSinkFunc func(ctx context.Context, videoID string, cue SubtitleText) error
+
+func (f CueSinkFunc) Send(ctx context.Context, videoID string, cue SubtitleText) error {
+	return f(ctx, videoID, cue)
+}
+
+func (c *Caption) Publish(ctx context.Context, sink CueSink) error {
+	for cue := range c.Cues() {
+		if err := sink.Send(ctx, c.VideoID, cue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func StreamToSink(ctx context.Context, videoID string, opts *Options, sink CueSink) error {
+	return NewClient(opts).StreamToSink(ctx, videoID, sink)
+}
+
+func (c *Client) StreamToSink(ctx context.Context, videoID string, sink CueSink) error {
+	return c.DownloadStream(ctx, videoID, func(cue SubtitleText) error {
+		return sink.Send(ctx, videoID, cue)
+	})
+}
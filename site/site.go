@@ -0,0 +1,260 @@
+package site
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	caption "github.com/lincaiyong/youtube-caption"
+)
+
+type Options struct {
+	Title string
+}
+
+type page struct {
+	ID        string
+	Title     string
+	Author    string
+	Thumbnail string
+	Cues      []pageCue
+}
+
+type pageCue struct {
+	ID    string
+	Clock string
+	URL   string
+	Text  string
+	Start float64
+}
+
+type searchEntry struct {
+	ID    string      `json:"id"`
+	Title string      `json:"title"`
+	Cues  [][2]string `json:"cues"`
+}
+
+var indexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>body{font-family:sans-serif;max-width:50em;margin:auto;padding:1em}li{margin:.3em 0}</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<input id="q" type="search" placeholder="Search all transcripts" autofocus>
+<ul id="results"></ul>
+<ul id="videos">
+{{- range .Pages}}
+<li><a href="videos/{{.ID}}.html">{{.Title}}</a>{{if .Author}} — {{.Author}}{{end}}</li>
+{{- end}}
+</ul>
+<script src="search.js"></script>
+<script>
+const q = document.getElementById("q"), results = document.getElementById("results"), videos = document.getElementById("videos");
+q.addEventListener("input", () => {
+  const term = q.value.trim().toLowerCase();
+  results.replaceChildren();
+  videos.hidden = term !== "";
+  if (term === "") return;
+  for (const t of TRANSCRIPTS) {
+    for (const [id, text] of t.cues) {
+      if (!text.toLowerCase().includes(term)) continue;
+      const li = document.createElement("li"), a = document.createElement("a");
+      a.href = "videos/" + t.id + ".html#c-" + id;
+      a.textContent = t.title + ": " + text;
+      li.append(a);
+      results.append(li);
+    }
+  }
+});
+</script>
+</body>
+</html>
+`))
+
+var pageTemplate = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>body{font-family:sans-serif;max-width:50em;margin:auto;padding:1em}p:target{background:#ffa}img{max-width:100%}</style>
+</head>
+<body>
+<p><a href="../index.html">&larr; All videos</a></p>
+<h1>{{.Title}}</h1>
+{{- if .Thumbnail}}
+<img src="{{.Thumbnail}}" alt="{{.Title}}">
+{{- end}}
+<input id="q" type="search" placeholder="Search this transcript">
+<div id="cues">
+{{- range .Cues}}
+<p id="c-{{.ID}}"><a href="{{.URL}}">{{.Clock}}</a> {{.Text}}</p>
+{{- end}}
+</div>
+<script>
+const q = document.getElementById("q");
+q.addEventListener("input", () => {
+  const term = q.value.trim().toLowerCase();
+  for (const p of document.querySelectorAll("#cues p")) {
+    p.hidden = term !== "" && !p.textContent.toLowerCase().includes(term);
+  }
+});
+</script>
+</body>
+</html>
+`))
+
+func loadCaption(filename string) (*caption.Caption, error) {
+	name := strings.ToLower(filepath.Base(filename))
+	switch {
+	case strings.HasSuffix(name, ".metadata.json"), strings.HasSuffix(name, ".jsonl"):
+		return nil, nil
+	case strings.HasSuffix(name, ".zip"):
+		return caption.LoadBundle(filename)
+	case strings.HasSuffix(name, caption.FormatCues.Ext()), strings.HasSuffix(name, ".srt"), strings.HasSuffix(name, ".vtt"):
+		return caption.LoadFile(filename)
+	case strings.HasSuffix(name, ".json"):
+		return caption.LoadFromFile(filename)
+	default:
+		return nil, nil
+	}
+}
+
+func loadMetadata(dir, videoID string, c *caption.Caption) {
+	data, err := os.ReadFile(filepath.Join(dir, videoID+".metadata.json"))
+	if err != nil {
+		return
+	}
+	var metadata caption.BundleMetadata
+	if json.Unmarshal(data, &metadata) == nil {
+		if c.Video == nil {
+			c.Video = metadata.Video
+		}
+		if c.Track == nil {
+			c.Track = metadata.Track
+		}
+	}
+}
+
+func newPage(c *caption.Caption) page {
+	p := page{ID: c.VideoID, Title: c.VideoID}
+	if c.Video != nil {
+		if c.Video.Title != "" {
+			p.Title = c.Video.Title
+		}
+		p.Author = c.Video.Author
+		p.Thumbnail = c.Video.ThumbnailURL()
+	}
+	if p.Thumbnail == "" {
+		p.Thumbnail = caption.ThumbnailURL(c.VideoID)
+	}
+	for _, cue := range c.WithLinks(c.VideoID) {
+		p.Cues = append(p.Cues, pageCue{
+			ID:    cue.ID(c.VideoID),
+			Clock: formatClock(cue.StartTime),
+			URL:   cue.URL,
+			Text:  cue.Text,
+			Start: cue.StartTime,
+		})
+	}
+	return p
+}
+
+func formatClock(seconds float64) string {
+	total := max(int(seconds), 0)
+	if total >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", total/3600, total/60%60, total%60)
+	}
+	return fmt.Sprintf("%02d:%02d", total/60, total%60)
+}
+
+func Generate(srcDir, outDir string, opts *Options) error {
+	if opts == nil {
+		opts = &Options{}
+	}
+	title := opts.Title
+	if title == "" {
+		title = "Transcripts"
+	}
+
+	entries, err := os.ReadDir(srcDir)
+	if err != nil {
+		return err
+	}
+	seen := make(map[string]bool)
+	var pages []page
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		filename := filepath.Join(srcDir, entry.Name())
+		c, err := loadCaption(filename)
+		if err != nil {
+			return fmt.Errorf("failed to load %s: %w", filename, err)
+		}
+		if c == nil {
+			continue
+		}
+		if c.VideoID == "" {
+			c.VideoID, _, _ = strings.Cut(entry.Name(), ".")
+		}
+		if _, err = caption.ExtractVideoID(c.VideoID); err != nil || seen[c.VideoID] {
+			continue
+		}
+		seen[c.VideoID] = true
+		loadMetadata(srcDir, c.VideoID, c)
+		pages = append(pages, newPage(c))
+	}
+	if len(pages) == 0 {
+		return errors.New("no transcripts found in " + srcDir)
+	}
+	sort.Slice(pages, func(i, j int) bool {
+		return strings.ToLower(pages[i].Title) < strings.ToLower(pages[j].Title)
+	})
+
+	if err = os.MkdirAll(filepath.Join(outDir, "videos"), 0755); err != nil {
+		return err
+	}
+	index := make([]searchEntry, 0, len(pages))
+	for _, p := range pages {
+		if err = writeTemplate(filepath.Join(outDir, "videos", p.ID+".html"), pageTemplate, p); err != nil {
+			return err
+		}
+		entry := searchEntry{ID: p.ID, Title: p.Title, Cues: make([][2]string, len(p.Cues))}
+		for i, cue := range p.Cues {
+			entry.Cues[i] = [2]string{cue.ID, cue.Text}
+		}
+		index = append(index, entry)
+	}
+
+	data, err := json.Marshal(index)
+	if err != nil {
+		return fmt.Errorf("failed to marshal search index: %w", err)
+	}
+	if err = os.WriteFile(filepath.Join(outDir, "search.js"), []byte("const TRANSCRIPTS = "+string(data)+";\n"), 0644); err != nil {
+		return err
+	}
+	return writeTemplate(filepath.Join(outDir, "index.html"), indexTemplate, struct {
+		Title string
+		Pages []page
+	}{title, pages})
+}
+
+func writeTemplate(filename string, tmpl *template.Template, data any) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if err = tmpl.Execute(f, data); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to render %s: %w", filename, err)
+	}
+	return f.Close()
+}
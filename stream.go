@@ -0,0 +1,182 @@
+package caption
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+type StreamFormat string
+
+const (
+	StreamNDJSON StreamFormat = "ndjson"
+	StreamSSE    StreamFormat = "sse"
+)
+
+func (f StreamFormat) ContentType() string {
+	if f == StreamSSE {
+		return "text/event-stream"
+	}
+	return "application/x-ndjson"
+}
+
+type StreamWriter struct {
+	w      io.Writer
+	format StreamFormat
+	count  int
+}
+
+func NewStreamWriter(w io.Writer, format StreamFormat) *StreamWriter {
+	return &StreamWriter{w: w, format: format}
+}
+
+func (s *StreamWriter) WriteCue(cue SubtitleText) error {
+	data, err := json.Marshal(cue)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cue: %w", err)
+	}
+	if s.format == StreamSSE {
+		_, err = fmt.Fprintf(s.w, "id: %d\nevent: cue\ndata: %s\n\n", s.count, data)
+	} else {
+		_, err = fmt.Fprintf(s.w, "%s\n", data)
+	}
+	if err != nil {
+		return err
+	}
+	s.count++
+	s.flush()
+	return nil
+}
+
+func (s *StreamWriter) Close() error {
+	if s.format != StreamSSE {
+		return nil
+	}
+	_, err := fmt.Fprintf(s.w, "event: done\ndata: {\"count\":%d}\n\n", s.count)
+	s.flush()
+	return err
+}
+
+func (s *StreamWriter) flush() {
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (c *Caption) Stream(sw *StreamWriter) error {
+	for cue := range c.Cues() {
+		if err := sw.WriteCue(cue); err != nil {
+			return err
+		}
+	}
+	return sw.Close()
+}
+
+func decodeEvents(r io.Reader, fn func(CaptionEvent, []CaptionPen) error) error {
+	dec := json.NewDecoder(r)
+	if tok, err := dec.Token(); err != nil {
+		return err
+	} else if tok != json.Delim('{') {
+		return fmt.Errorf("unexpected token %v", tok)
+	}
+	var pens []CaptionPen
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := tok.(string)
+		if key == "pens" {
+			if err = dec.Decode(&pens); err != nil {
+				return err
+			}
+			continue
+		}
+		if key != "events" {
+			var skip json.RawMessage
+			if err = dec.Decode(&skip); err != nil {
+				return err
+			}
+			continue
+		}
+		if tok, err = dec.Token(); err != nil {
+			return err
+		} else if tok != json.Delim('[') {
+			return fmt.Errorf("unexpected token %v", tok)
+		}
+		for dec.More() {
+			var event CaptionEvent
+			if err = dec.Decode(&event); err != nil {
+				return err
+			}
+			if err = fn(event, pens); err != nil {
+				return err
+			}
+		}
+		if _, err = dec.Token(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func DownloadStream(ctx context.Context, videoID string, opts *Options, fn func(SubtitleText) error) error {
+	return NewClient(opts).DownloadStream(ctx, videoID, fn)
+}
+
+func (c *Client) DownloadStream(ctx context.Context, videoID string, fn func(SubtitleText) error) error {
+	if err := validateVideoID(videoID); err != nil {
+		return err
+	}
+	ctx, end, err := c.begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer end()
+
+	track, _, warnings, err := c.requestCaptionTrack(ctx, videoID)
+	if err != nil {
+		return err
+	}
+	c.warn(videoID, warnings)
+
+	resp, err := c.requestTimedTextResponse(ctx, track)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var fnErr error
+	events, skipped := 0, 0
+	counter := &countingReader{r: resp.Body, max: c.opts.MaxResponseBytes}
+	err = decodeEvents(counter, func(event CaptionEvent, pens []CaptionPen) error {
+		if !c.opts.keepEvent(event) {
+			skipped++
+			return nil
+		}
+		if c.opts.MaxEvents > 0 && events >= c.opts.MaxEvents {
+			return errEventLimit
+		}
+		events++
+		if sub, ok := eventToSubtitle(event, pens); ok {
+			fnErr = fn(sub)
+		}
+		return fnErr
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if limitErr := c.opts.limitError(err, counter.n, events, nil); limitErr != nil {
+			return newStageError(ErrTrackFetch, limitErr, nil)
+		}
+		return newStageError(ErrParse, fmt.Errorf("failed to decode subtitle stream: %w", err), nil)
+	}
+	c.warn(videoID, skippedWarning(skipped))
+	return nil
+}
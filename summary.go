@@ -0,0 +1,144 @@
+package caption
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const defaultSummaryChunkTokens = 2000
+
+type Summarizer interface {
+	Summarize(ctx context.Context, text string) (string, error)
+}
+
+type Chapter struct {
+	Title string
+	Start float64
+}
+
+type SummaryOptions struct {
+	Chapters []Chapter
+	Chunk    *ChunkOptions
+}
+
+type SectionSummary struct {
+	Title   string
+	Start   float64
+	End     float64
+	Summary string
+}
+
+type Summary struct {
+	Sections []SectionSummary
+}
+
+var chapterLineRegex = regexp.MustCompile(`^\s*[-•*]?\s*\(?((?:\d{1,2}:)?\d{1,2}:\d{2})\)?\s*[-–—:|]?\s*(.+?)\s*$`)
+
+func ParseChapters(description string) []Chapter {
+	var chapters []Chapter
+	for _, line := range strings.Split(description, "\n") {
+		m := chapterLineRegex.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		start, err := parseTimecode(m[1])
+		if err != nil {
+			continue
+		}
+		if n := len(chapters); n > 0 && start <= chapters[n-1].Start {
+			continue
+		}
+		chapters = append(chapters, Chapter{Title: m[2], Start: start})
+	}
+	if len(chapters) < 2 || chapters[0].Start != 0 {
+		return nil
+	}
+	return chapters
+}
+
+func (v *VideoInfo) Chapters() []Chapter {
+	return ParseChapters(v.Description)
+}
+
+func (c *Caption) chapterSections(chapters []Chapter) []SectionSummary {
+	subtitles := c.GetSubtitleText()
+	sections := make([]SectionSummary, 0, len(chapters))
+	texts := make([][]string, len(chapters))
+	for _, chapter := range chapters {
+		sections = append(sections, SectionSummary{Title: chapter.Title, Start: chapter.Start, End: chapter.Start})
+	}
+	for _, sub := range subtitles {
+		i := len(chapters) - 1
+		for i > 0 && sub.StartTime < chapters[i].Start {
+			i--
+		}
+		texts[i] = append(texts[i], strings.ReplaceAll(sub.Text, "\n", " "))
+		if sub.EndTime > sections[i].End {
+			sections[i].End = sub.EndTime
+		}
+	}
+	for i := range sections {
+		sections[i].Summary = strings.Join(texts[i], " ")
+	}
+	return sections
+}
+
+func (c *Caption) Summarize(ctx context.Context, s Summarizer) (*Summary, error) {
+	return c.SummarizeWithOptions(ctx, s, nil)
+}
+
+func (c *Caption) SummarizeWithOptions(ctx context.Context, s Summarizer, opts *SummaryOptions) (*Summary, error) {
+	if opts == nil {
+		opts = &SummaryOptions{}
+	}
+	chapters := opts.Chapters
+	if len(chapters) == 0 && c.Video != nil {
+		chapters = c.Video.Chapters()
+	}
+
+	var sections []SectionSummary
+	if len(chapters) > 0 {
+		sections = c.chapterSections(chapters)
+	} else {
+		chunkOpts := ChunkOptions{MaxTokens: defaultSummaryChunkTokens}
+		if opts.Chunk != nil {
+			chunkOpts = *opts.Chunk
+		}
+		for _, chunk := range c.Chunks(&chunkOpts) {
+			sections = append(sections, SectionSummary{Start: chunk.Start, End: chunk.End, Summary: chunk.Text})
+		}
+	}
+
+	summary := &Summary{}
+	for _, section := range sections {
+		if strings.TrimSpace(section.Summary) == "" {
+			continue
+		}
+		text, err := s.Summarize(ctx, section.Summary)
+		if err != nil {
+			return nil, fmt.Errorf("failed to summarize section at %s: %w", formatClock(section.Start), err)
+		}
+		section.Summary = text
+		summary.Sections = append(summary.Sections, section)
+	}
+	return summary, nil
+}
+
+func (s *Summary) String() string {
+	var result strings.Builder
+	for i, section := range s.Sections {
+		if i > 0 {
+			result.WriteString("\n")
+		}
+		result.WriteString("[" + formatClock(section.Start) + "]")
+		if section.Title != "" {
+			result.WriteString(" " + section.Title)
+		}
+		result.WriteString("\n")
+		result.WriteString(strings.TrimSpace(section.Summary))
+		result.WriteString("\n")
+	}
+	return result.String()
+}
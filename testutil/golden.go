@@ -0,0 +1,124 @@
+package testutil
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	caption "github.com/lincaiyong/youtube-caption"
+)
+
+const UpdateEnv = "YTCAPTION_UPDATE_GOLDEN"
+
+const sampleSRT = `1
+00:00:00,000 --> 00:00:02,500
+Hello and welcome to the show.
+
+2
+00:00:02,500 --> 00:00:05,000
+Today: café, naïve & "quoted" <text>
+
+3
+00:00:05,000 --> 00:00:08,250
+A cue that spans
+two lines.
+
+4
+01:00:00,000 --> 01:00:01,001
+An hour later.
+`
+
+func SampleCaption() *caption.Caption {
+	c, err := caption.ParseSRT(strings.NewReader(sampleSRT))
+	if err != nil {
+		panic(err)
+	}
+	c.VideoID = "vStJoetOxJg"
+	return c
+}
+
+type Normalizer func([]byte) []byte
+
+func NormalizeLineEndings(data []byte) []byte {
+	data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+	return bytes.ReplaceAll(data, []byte("\r"), []byte("\n"))
+}
+
+func TrimTrailingSpace(data []byte) []byte {
+	lines := bytes.Split(data, []byte("\n"))
+	for i, line := range lines {
+		lines[i] = bytes.TrimRight(line, " \t")
+	}
+	return append(bytes.TrimRight(bytes.Join(lines, []byte("\n")), "\n"), '\n')
+}
+
+var DefaultNormalizers = []Normalizer{NormalizeLineEndings, TrimTrailingSpace}
+
+func Normalize(data []byte, normalizers ...Normalizer) []byte {
+	if len(normalizers) == 0 {
+		normalizers = DefaultNormalizers
+	}
+	for _, normalize := range normalizers {
+		data = normalize(data)
+	}
+	return data
+}
+
+func GoldenPath(name string) string {
+	return filepath.Join("testdata", name+".golden")
+}
+
+func AssertGolden(t testing.TB, name string, got []byte, normalizers ...Normalizer) {
+	t.Helper()
+	path := GoldenPath(name)
+	got = Normalize(got, normalizers...)
+	if os.Getenv(UpdateEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create golden dir: %v", err)
+		}
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatalf("failed to update golden file: %v", err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file (run with %s=1 to create it): %v", UpdateEnv, err)
+	}
+	if diff := Diff(Normalize(want, normalizers...), got); diff != "" {
+		t.Errorf("%s mismatch (run with %s=1 to update):\n%s", path, UpdateEnv, diff)
+	}
+}
+
+func AssertFormat(t testing.TB, c *caption.Caption, format caption.Format, normalizers ...Normalizer) {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := c.Write(&buf, format); err != nil {
+		t.Fatalf("failed to write %s: %v", format, err)
+	}
+	AssertGolden(t, string(format), buf.Bytes(), normalizers...)
+}
+
+func Diff(want, got []byte) string {
+	if bytes.Equal(want, got) {
+		return ""
+	}
+	wantLines := strings.Split(string(want), "\n")
+	gotLines := strings.Split(string(got), "\n")
+	for i := 0; i < max(len(wantLines), len(gotLines)); i++ {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w != g {
+			return fmt.Sprintf("line %d:\n-%s\n+%s", i+1, w, g)
+		}
+	}
+	return ""
+}
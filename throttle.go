@@ -0,0 +1,129 @@
+package caption
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultThrottleMaxDelay  = time.Minute
+	defaultLatencyThreshold  = 2 * time.Second
+	throttleBackoffFactor    = 2.0
+	throttleSlowFactor       = 1.5
+	throttleRecoveryFactor   = 0.9
+	throttleInitialSlowDelay = 500 * time.Millisecond
+)
+
+type AdaptiveThrottle struct {
+	MinDelay         time.Duration
+	MaxDelay         time.Duration
+	LatencyThreshold time.Duration
+}
+
+type adaptiveThrottle struct {
+	mu    sync.Mutex
+	cfg   AdaptiveThrottle
+	delay time.Duration
+	next  time.Time
+	clock Clock
+}
+
+func newAdaptiveThrottle(cfg *AdaptiveThrottle, clock Clock) *adaptiveThrottle {
+	if cfg == nil {
+		return nil
+	}
+	t := &adaptiveThrottle{cfg: *cfg, clock: clock}
+	if t.cfg.MaxDelay <= 0 {
+		t.cfg.MaxDelay = defaultThrottleMaxDelay
+	}
+	if t.cfg.LatencyThreshold <= 0 {
+		t.cfg.LatencyThreshold = defaultLatencyThreshold
+	}
+	t.delay = t.cfg.MinDelay
+	return t
+}
+
+func (t *adaptiveThrottle) wait(ctx context.Context) error {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	now := t.clock.Now()
+	at := t.next
+	if at.Before(now) {
+		at = now
+	}
+	t.next = at.Add(t.delay)
+	t.mu.Unlock()
+
+	return sleep(ctx, t.clock, at.Sub(now))
+}
+
+func (t *adaptiveThrottle) clamp(d time.Duration) time.Duration {
+	if d < t.cfg.MinDelay {
+		return t.cfg.MinDelay
+	}
+	if d > t.cfg.MaxDelay {
+		return t.cfg.MaxDelay
+	}
+	return d
+}
+
+func (t *adaptiveThrottle) observe(resp *http.Response, latency time.Duration) {
+	if t == nil || resp == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable:
+		d := time.Duration(float64(t.delay) * throttleBackoffFactor)
+		if d < throttleInitialSlowDelay {
+			d = throttleInitialSlowDelay
+		}
+		now := t.clock.Now()
+		if retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"), now); retryAfter > d {
+			d = retryAfter
+			t.next = now.Add(retryAfter)
+		}
+		t.delay = t.clamp(d)
+	case latency > t.cfg.LatencyThreshold:
+		d := time.Duration(float64(t.delay) * throttleSlowFactor)
+		if d < throttleInitialSlowDelay {
+			d = throttleInitialSlowDelay
+		}
+		t.delay = t.clamp(d)
+	default:
+		t.delay = t.clamp(time.Duration(float64(t.delay) * throttleRecoveryFactor))
+	}
+}
+
+func (t *adaptiveThrottle) Delay() time.Duration {
+	if t == nil {
+		return 0
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.delay
+}
+
+func parseRetryAfter(v string, now time.Time) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if at, err := http.ParseTime(v); err == nil {
+		return at.Sub(now)
+	}
+	return 0
+}
+
+func (c *Client) ThrottleDelay() time.Duration {
+	return c.throttle.Delay()
+}
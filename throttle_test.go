@@ -0,0 +1,148 @@
+package caption
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fixedRand float64
+
+func (r fixedRand) Float64() float64 {
+	return float64(r)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := map[string]time.Duration{
+		"":                              0,
+		"120":                           2 * time.Minute,
+		"0":                             0,
+		"-5":                            0,
+		"soon":                          0,
+		"Thu, 01 Jan 2026 00:00:30 GMT": 30 * time.Second,
+		"Wed, 31 Dec 2025 23:59:00 GMT": -time.Minute,
+	}
+	for v, want := range tests {
+		if got := parseRetryAfter(v, now); got != want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", v, got, want)
+		}
+	}
+}
+
+func TestAdaptiveThrottleWithFakeClock(t *testing.T) {
+	clock := newFakeClock()
+	throttle := newAdaptiveThrottle(&AdaptiveThrottle{}, clock)
+	ctx := context.Background()
+
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	resp.Header.Set("Retry-After", clock.Now().Add(30*time.Second).Format(http.TimeFormat))
+	throttle.observe(resp, 0)
+	if got := throttle.Delay(); got != 30*time.Second {
+		t.Fatalf("delay after Retry-After = %v, want 30s", got)
+	}
+	if err := throttle.wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := throttle.wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if want := []time.Duration{30 * time.Second, 30 * time.Second}; !reflect.DeepEqual(clock.slept, want) {
+		t.Errorf("slept %v, want %v", clock.slept, want)
+	}
+
+	throttle.observe(&http.Response{StatusCode: http.StatusOK}, 3*time.Second)
+	if got := throttle.Delay(); got != 45*time.Second {
+		t.Errorf("delay after a slow response = %v, want 45s", got)
+	}
+	throttle.observe(&http.Response{StatusCode: http.StatusOK}, 0)
+	if got := throttle.Delay(); got != time.Duration(float64(45*time.Second)*throttleRecoveryFactor) {
+		t.Errorf("delay after a fast response = %v, want it to recover", got)
+	}
+}
+
+func TestRetryBackoffWithFakeClock(t *testing.T) {
+	clock := newFakeClock()
+	attempts := 0
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		status := http.StatusInternalServerError
+		if attempts == 3 {
+			status = http.StatusOK
+		}
+		return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader("")), Header: http.Header{}, Request: req}, nil
+	})
+	c := NewClient(&Options{Transport: transport, MaxRetries: 3, Clock: clock, Rand: fixedRand(0.5)})
+	req, _ := http.NewRequest("GET", "https://www.youtube.com/", nil)
+	start := time.Now()
+	resp, err := c.do(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if attempts != 3 {
+		t.Errorf("%d attempts, want 3", attempts)
+	}
+	if want := []time.Duration{500 * time.Millisecond, 750 * time.Millisecond}; !reflect.DeepEqual(clock.slept, want) {
+		t.Errorf("backoff slept %v, want %v", clock.slept, want)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("retries took %v of wall time with a fake clock", elapsed)
+	}
+}
+
+func TestSelfTestLatencyUsesClock(t *testing.T) {
+	clock := newFakeClock()
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		clock.Advance(250 * time.Millisecond)
+		return &http.Response{StatusCode: http.StatusForbidden, Body: io.NopCloser(strings.NewReader("")), Header: http.Header{}, Request: req}, nil
+	})
+	opts := DefaultOptions()
+	opts.Transport, opts.Clock = transport, clock
+	report := NewClient(opts).SelfTest(context.Background())
+	if len(report.Probes) == 0 {
+		t.Fatal("no probes")
+	}
+	for _, probe := range report.Probes {
+		if probe.Latency != 250*time.Millisecond {
+			t.Errorf("%s latency = %v, want 250ms", probe.Client.Name, probe.Latency)
+		}
+	}
+}
+
+func TestTrackURLExpiryUsesClientClock(t *testing.T) {
+	clock := newFakeClock()
+	c := NewClient(&Options{Clock: clock})
+	expires := clock.Now().Add(time.Hour).Unix()
+	u := TrackURL{URL: "https://www.youtube.com/api/timedtext?v=vStJoetOxJg&expire=" + strconv.FormatInt(expires, 10), client: c}
+	if u.IsExpired() {
+		t.Fatal("expired an hour early")
+	}
+	clock.Advance(time.Hour)
+	if !u.IsExpired() {
+		t.Fatal("not expired at the expire time")
+	}
+}
+
+func TestTrackListUsesClientClock(t *testing.T) {
+	clock := newFakeClock()
+	c := NewClient(&Options{Clock: clock})
+	expires := clock.Now().Add(time.Hour).Unix()
+	track := CaptionTrack{BaseURL: TrackURL{URL: "https://www.youtube.com/api/timedtext?v=vStJoetOxJg&expire=" + strconv.FormatInt(expires, 10)}}
+	l := newTrackList("vStJoetOxJg", []CaptionTrack{track}, c)
+	if !l.FetchedAt.Equal(clock.Now()) {
+		t.Errorf("fetched at %v, want %v", l.FetchedAt, clock.Now())
+	}
+	if l.IsExpired() {
+		t.Fatal("expired an hour early")
+	}
+	clock.Advance(time.Hour)
+	if !l.IsExpired() {
+		t.Fatal("not expired at the expire time")
+	}
+}
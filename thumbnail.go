@@ -0,0 +1,47 @@
+package caption
+
+type Thumbnail struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+type ThumbnailList struct {
+	Thumbnails []Thumbnail `json:"thumbnails,omitempty"`
+}
+
+type PageOptions struct {
+	Thumbnail bool
+}
+
+func ThumbnailURL(videoID string) string {
+	return "https://i.ytimg.com/vi/" + videoID + "/hqdefault.jpg"
+}
+
+func (v *VideoInfo) ThumbnailURL() string {
+	var best Thumbnail
+	for _, t := range v.Thumbnail.Thumbnails {
+		if t.Width*t.Height >= best.Width*best.Height {
+			best = t
+		}
+	}
+	if best.URL != "" {
+		return best.URL
+	}
+	if v.VideoID != "" {
+		return ThumbnailURL(v.VideoID)
+	}
+	return ""
+}
+
+func (c *Caption) thumbnailURL() string {
+	if c.Video != nil {
+		if url := c.Video.ThumbnailURL(); url != "" {
+			return url
+		}
+	}
+	if c.VideoID != "" {
+		return ThumbnailURL(c.VideoID)
+	}
+	return ""
+}
@@ -0,0 +1,66 @@
+package caption
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(math.Round(seconds*1000)) * time.Millisecond
+}
+
+func formatTimecode(d time.Duration, sep string) string {
+	ms := max(d.Milliseconds(), 0)
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d", ms/3600000, ms/60000%60, ms/1000%60, sep, ms%1000)
+}
+
+func formatSRTTime(d time.Duration) string {
+	return formatTimecode(d, ",")
+}
+
+func formatVTTTime(d time.Duration) string {
+	return formatTimecode(d, ".")
+}
+
+func formatTTMLTime(d time.Duration) string {
+	return formatTimecode(d, ".")
+}
+
+func formatASSTime(d time.Duration) string {
+	cs := max((d.Milliseconds()+5)/10, 0)
+	return fmt.Sprintf("%d:%02d:%02d.%02d", cs/360000, cs/6000%60, cs/100%60, cs%100)
+}
+
+func formatClock(seconds float64) string {
+	total := max(int64(seconds), 0)
+	if total >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", total/3600, total/60%60, total%60)
+	}
+	return fmt.Sprintf("%02d:%02d", total/60, total%60)
+}
+
+func parseTimecode(s string) (float64, error) {
+	s = strings.TrimSpace(strings.Replace(s, ",", ".", 1))
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid timecode %q", s)
+	}
+	var ms int64
+	for i, part := range parts[:len(parts)-1] {
+		n, err := strconv.ParseInt(part, 10, 64)
+		if err != nil || (i > 0 && n >= 60) || strings.ContainsAny(part, "+-") {
+			return 0, fmt.Errorf("invalid timecode %q", s)
+		}
+		ms = ms*60 + n
+	}
+	last := parts[len(parts)-1]
+	secs, err := strconv.ParseFloat(last, 64)
+	if err != nil || !(secs >= 0 && secs < 60) || strings.ContainsAny(last, "+-eExXpP_") {
+		return 0, fmt.Errorf("invalid timecode %q", s)
+	}
+	ms = ms*60000 + int64(math.Round(secs*1000))
+	return float64(ms) / 1000, nil
+}
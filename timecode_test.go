@@ -0,0 +1,126 @@
+package caption
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatTimecodes(t *testing.T) {
+	tests := []struct {
+		d        time.Duration
+		srt, vtt string
+		ass      string
+	}{
+		{0, "00:00:00,000", "00:00:00.000", "0:00:00.00"},
+		{999 * time.Millisecond, "00:00:00,999", "00:00:00.999", "0:00:01.00"},
+		{59*time.Minute + 59*time.Second + 999*time.Millisecond, "00:59:59,999", "00:59:59.999", "1:00:00.00"},
+		{time.Hour, "01:00:00,000", "01:00:00.000", "1:00:00.00"},
+		{23*time.Hour + 59*time.Minute + 59*time.Second + 999*time.Millisecond, "23:59:59,999", "23:59:59.999", "24:00:00.00"},
+		{25*time.Hour + time.Millisecond, "25:00:00,001", "25:00:00.001", "25:00:00.00"},
+		{100*time.Hour + 5*time.Minute + 7*time.Second + 40*time.Millisecond, "100:05:07,040", "100:05:07.040", "100:05:07.04"},
+		{-time.Second, "00:00:00,000", "00:00:00.000", "0:00:00.00"},
+		{-25 * time.Hour, "00:00:00,000", "00:00:00.000", "0:00:00.00"},
+	}
+	for _, tt := range tests {
+		if got := formatSRTTime(tt.d); got != tt.srt {
+			t.Errorf("formatSRTTime(%v) = %q, want %q", tt.d, got, tt.srt)
+		}
+		if got := formatVTTTime(tt.d); got != tt.vtt {
+			t.Errorf("formatVTTTime(%v) = %q, want %q", tt.d, got, tt.vtt)
+		}
+		if got := formatASSTime(tt.d); got != tt.ass {
+			t.Errorf("formatASSTime(%v) = %q, want %q", tt.d, got, tt.ass)
+		}
+	}
+}
+
+func TestFormatClock(t *testing.T) {
+	for seconds, want := range map[float64]string{
+		-5: "00:00", 0: "00:00", 59.9: "00:59", 3599: "59:59", 3600: "1:00:00", 90061: "25:01:01",
+	} {
+		if got := formatClock(seconds); got != want {
+			t.Errorf("formatClock(%v) = %q, want %q", seconds, got, want)
+		}
+	}
+}
+
+func TestFormatEDLTimecodeFrames(t *testing.T) {
+	tests := []struct {
+		seconds float64
+		fps     int
+		want    string
+	}{
+		{0, 30, "00:00:00:00"},
+		{1, 30, "00:00:01:00"},
+		{0.04, 25, "00:00:00:01"},
+		{0.5, 24, "00:00:00:12"},
+		{0.999, 30, "00:00:01:00"},
+		{59.99, 25, "00:01:00:00"},
+		{3599.99, 30, "01:00:00:00"},
+		{90000, 25, "25:00:00:00"},
+		{-1, 30, "00:00:00:00"},
+	}
+	for _, tt := range tests {
+		if got := formatEDLTimecode(tt.seconds, tt.fps); got != tt.want {
+			t.Errorf("formatEDLTimecode(%v, %d) = %q, want %q", tt.seconds, tt.fps, got, tt.want)
+		}
+	}
+}
+
+func TestParseTimecode(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    float64
+		wantErr bool
+	}{
+		{in: "00:00:01,500", want: 1.5},
+		{in: "00:00:01.500", want: 1.5},
+		{in: " 01:02:03.004 ", want: 3723.004},
+		{in: "01:30.25", want: 90.25},
+		{in: "1:05", want: 65},
+		{in: "90:00.000", want: 5400},
+		{in: "23:59:59.999", want: 86399.999},
+		{in: "24:00:00.000", want: 86400},
+		{in: "100:00:00,001", want: 360000.001},
+		{in: "00:00:00.0004", want: 0},
+		{in: "00:00:00.9996", want: 1},
+		{in: "-00:00:01.000", wantErr: true},
+		{in: "00:-1:00.000", wantErr: true},
+		{in: "00:00:-1.000", wantErr: true},
+		{in: "00:60:00.000", wantErr: true},
+		{in: "00:00:60.000", wantErr: true},
+		{in: "00:00:1e1", wantErr: true},
+		{in: "00:00:NaN", wantErr: true},
+		{in: "00:00:Inf", wantErr: true},
+		{in: "+1:00:00.000", wantErr: true},
+		{in: "00:00:01,500,000", wantErr: true},
+		{in: "1:2:3:4", wantErr: true},
+		{in: "12.5", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseTimecode(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseTimecode(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("parseTimecode(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestTimecodeRoundTrip(t *testing.T) {
+	for _, ms := range []int64{0, 1, 999, 1000, 59999, 3599999, 3600000, 86399999, 90000001} {
+		d := time.Duration(ms) * time.Millisecond
+		for _, s := range []string{formatSRTTime(d), formatVTTTime(d)} {
+			got, err := parseTimecode(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if secondsToDuration(got) != d {
+				t.Errorf("parseTimecode(%q) = %v, want %v", s, got, d.Seconds())
+			}
+		}
+	}
+}
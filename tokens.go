@@ -0,0 +1,34 @@
+package caption
+
+import "unicode/utf8"
+
+type TokenCounter interface {
+	CountTokens(text string) int
+}
+
+type TokenCounterFunc func(text string) int
+
+func (f TokenCounterFunc) CountTokens(text string) int {
+	return f(text)
+}
+
+var ApproxTokenCounter TokenCounter = TokenCounterFunc(func(text string) int {
+	return (utf8.RuneCountInString(text) + 3) / 4
+})
+
+type TokenCue struct {
+	SubtitleText
+	Tokens int `json:"tokens"`
+}
+
+func (c *Caption) AnnotateTokens(counter TokenCounter) []TokenCue {
+	if counter == nil {
+		counter = ApproxTokenCounter
+	}
+	subtitles := c.GetSubtitleText()
+	result := make([]TokenCue, len(subtitles))
+	for i, sub := range subtitles {
+		result[i] = TokenCue{SubtitleText: sub, Tokens: counter.CountTokens(sub.Text)}
+	}
+	return result
+}
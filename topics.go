@@ -0,0 +1,258 @@
+package caption
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+const (
+	defaultTopicWindowWords = 20
+	defaultTopicBlockSize   = 6
+	defaultTopicKeywords    = 5
+)
+
+var stopWords = toSet(strings.Fields(`a about above after again against all am an and any are as at be because been
+	before being below between both but by can could did do does doing down during each few for from further had has
+	have having he her here hers herself him himself his how i if in into is it its itself just like me more most my
+	myself no nor not now of off on once only or other our ours ourselves out over own really right same she should
+	so some such than that the their theirs them themselves then there these they this those through to too under
+	until up very was we were what when where which while who whom why will with would you your yours yourself
+	yourselves gonna going get got know okay oh yeah um uh also let thing things see one two lot kind actually well`))
+
+func toSet(words []string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		set[word] = true
+	}
+	return set
+}
+
+type TopicOptions struct {
+	WindowWords       int
+	BlockSize         int
+	Keywords          int
+	MinSectionSeconds float64
+}
+
+type TopicSection struct {
+	Start    float64
+	End      float64
+	Text     string
+	Keywords []string
+}
+
+type topicWord struct {
+	norm string
+	cue  int
+}
+
+func (o *TopicOptions) withDefaults() TopicOptions {
+	opts := TopicOptions{}
+	if o != nil {
+		opts = *o
+	}
+	if opts.WindowWords <= 0 {
+		opts.WindowWords = defaultTopicWindowWords
+	}
+	if opts.BlockSize <= 0 {
+		opts.BlockSize = defaultTopicBlockSize
+	}
+	if opts.Keywords <= 0 {
+		opts.Keywords = defaultTopicKeywords
+	}
+	return opts
+}
+
+func (c *Caption) Topics(opts *TopicOptions) []TopicSection {
+	o := opts.withDefaults()
+	subs := c.GetSubtitleText()
+	if len(subs) == 0 {
+		return nil
+	}
+
+	var words []topicWord
+	for i, sub := range subs {
+		for _, word := range strings.Fields(sub.Text) {
+			if norm := normalizeWord(word); norm != "" && !stopWords[norm] && len([]rune(norm)) > 2 {
+				words = append(words, topicWord{norm: norm, cue: i})
+			}
+		}
+	}
+
+	var windows []map[string]int
+	for start := 0; start < len(words); start += o.WindowWords {
+		counts := make(map[string]int)
+		for _, word := range words[start:min(start+o.WindowWords, len(words))] {
+			counts[word.norm]++
+		}
+		windows = append(windows, counts)
+	}
+
+	var boundaries []int
+	if len(windows) > 2 {
+		scores := make([]float64, len(windows)-1)
+		for gap := range scores {
+			left := mergeCounts(windows[max(0, gap+1-o.BlockSize) : gap+1])
+			right := mergeCounts(windows[gap+1 : min(len(windows), gap+1+o.BlockSize)])
+			scores[gap] = cosine(left, right)
+		}
+		depths := depthScores(smooth(scores))
+		mean, std := meanStd(depths)
+		for gap, depth := range depths {
+			if depth > mean+std/2 && depth > 0 {
+				boundaries = append(boundaries, words[(gap+1)*o.WindowWords].cue)
+			}
+		}
+	}
+
+	starts := []int{0}
+	for _, cue := range boundaries {
+		if cue <= starts[len(starts)-1] {
+			continue
+		}
+		if subs[cue].StartTime-subs[starts[len(starts)-1]].StartTime < o.MinSectionSeconds {
+			continue
+		}
+		starts = append(starts, cue)
+	}
+
+	sections := make([]TopicSection, len(starts))
+	counts := make([]map[string]int, len(starts))
+	for i, start := range starts {
+		end := len(subs)
+		if i+1 < len(starts) {
+			end = starts[i+1]
+		}
+		texts := make([]string, 0, end-start)
+		for _, sub := range subs[start:end] {
+			texts = append(texts, strings.ReplaceAll(sub.Text, "\n", " "))
+			sections[i].End = max(sections[i].End, sub.EndTime)
+		}
+		sections[i].Start = subs[start].StartTime
+		sections[i].Text = strings.Join(texts, " ")
+		counts[i] = make(map[string]int)
+	}
+	section := 0
+	for _, word := range words {
+		for section+1 < len(starts) && word.cue >= starts[section+1] {
+			section++
+		}
+		counts[section][word.norm]++
+	}
+	for i := range sections {
+		sections[i].Keywords = topKeywords(counts, i, o.Keywords)
+	}
+	return sections
+}
+
+func (c *Caption) TopicChapters(opts *TopicOptions) []Chapter {
+	sections := c.Topics(opts)
+	chapters := make([]Chapter, len(sections))
+	for i, section := range sections {
+		chapters[i] = Chapter{Title: strings.Join(section.Keywords, ", "), Start: section.Start}
+	}
+	if len(chapters) > 0 {
+		chapters[0].Start = 0
+	}
+	return chapters
+}
+
+func mergeCounts(windows []map[string]int) map[string]int {
+	merged := make(map[string]int)
+	for _, window := range windows {
+		for word, n := range window {
+			merged[word] += n
+		}
+	}
+	return merged
+}
+
+func cosine(a, b map[string]int) float64 {
+	var dot, normA, normB float64
+	for word, n := range a {
+		dot += float64(n * b[word])
+		normA += float64(n * n)
+	}
+	for _, n := range b {
+		normB += float64(n * n)
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(normA*normB)
+}
+
+func smooth(scores []float64) []float64 {
+	smoothed := make([]float64, len(scores))
+	for i := range scores {
+		from, to := max(0, i-1), min(len(scores), i+2)
+		var sum float64
+		for _, score := range scores[from:to] {
+			sum += score
+		}
+		smoothed[i] = sum / float64(to-from)
+	}
+	return smoothed
+}
+
+func depthScores(scores []float64) []float64 {
+	depths := make([]float64, len(scores))
+	for i, score := range scores {
+		left := score
+		for j := i - 1; j >= 0 && scores[j] >= left; j-- {
+			left = scores[j]
+		}
+		right := score
+		for j := i + 1; j < len(scores) && scores[j] >= right; j++ {
+			right = scores[j]
+		}
+		depths[i] = (left - score) + (right - score)
+	}
+	return depths
+}
+
+func meanStd(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+	var variance float64
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(variance / float64(len(values)))
+}
+
+func topKeywords(counts []map[string]int, section, n int) []string {
+	type scored struct {
+		word  string
+		score float64
+	}
+	var candidates []scored
+	for word, tf := range counts[section] {
+		df := 0
+		for _, other := range counts {
+			if other[word] > 0 {
+				df++
+			}
+		}
+		idf := math.Log(float64(len(counts))/float64(df)) + 1
+		candidates = append(candidates, scored{word: word, score: float64(tf) * idf})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score > candidates[j].score
+		}
+		return candidates[i].word < candidates[j].word
+	})
+	keywords := make([]string, 0, n)
+	for _, candidate := range candidates[:min(n, len(candidates))] {
+		keywords = append(keywords, candidate.word)
+	}
+	return keywords
+}
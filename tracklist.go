@@ -0,0 +1,91 @@
+package caption
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+type TrackList struct {
+	VideoID   string         `json:"videoId"`
+	FetchedAt time.Time      `json:"fetchedAt"`
+	ExpiresAt time.Time      `json:"expiresAt,omitzero"`
+	Tracks    []CaptionTrack `json:"tracks"`
+
+	client *Client
+}
+
+func NewTrackList(videoID string, tracks []CaptionTrack) *TrackList {
+	return newTrackList(videoID, tracks, nil)
+}
+
+func newTrackList(videoID string, tracks []CaptionTrack, client *Client) *TrackList {
+	l := &TrackList{VideoID: videoID, Tracks: tracks, client: client}
+	l.FetchedAt = l.clock().Now()
+	if l.Tracks == nil {
+		l.Tracks = []CaptionTrack{}
+	}
+	for _, track := range tracks {
+		if expires := track.BaseURL.ExpiresAt(); !expires.IsZero() && (l.ExpiresAt.IsZero() || expires.Before(l.ExpiresAt)) {
+			l.ExpiresAt = expires
+		}
+	}
+	return l
+}
+
+func (c *Client) GetTrackList(ctx context.Context, videoID string) (*TrackList, error) {
+	tracks, err := c.GetAvailableTracks(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+	return newTrackList(videoID, tracks, c), nil
+}
+
+func (l *TrackList) clock() Clock {
+	if l.client != nil {
+		return l.client.opts.clock()
+	}
+	return SystemClock
+}
+
+func (l *TrackList) IsExpired() bool {
+	return !l.ExpiresAt.IsZero() && !l.clock().Now().Before(l.ExpiresAt)
+}
+
+func (l *TrackList) Refresh(ctx context.Context, c *Client) error {
+	if c == nil {
+		c = NewClient(nil)
+	}
+	fresh, err := c.GetTrackList(ctx, l.VideoID)
+	if err != nil {
+		return fmt.Errorf("failed to refresh track list: %w", err)
+	}
+	*l = *fresh
+	return nil
+}
+
+func (l *TrackList) Save(filename string) error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal track list: %w", err)
+	}
+	return writeFileWith(filename, &SaveOptions{Atomic: true}, func(w io.Writer) error {
+		_, err := w.Write(append(data, '\n'))
+		return err
+	})
+}
+
+func LoadTrackList(filename string) (*TrackList, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var l TrackList
+	if err = json.Unmarshal(data, &l); err != nil {
+		return nil, fmt.Errorf("failed to parse track list: %w", err)
+	}
+	return &l, nil
+}
@@ -0,0 +1,42 @@
+package caption
+
+import "strings"
+
+type TrackUnusableReason string
+
+const (
+	TrackLoginRequired    TrackUnusableReason = "login_required"
+	TrackAgeRestricted    TrackUnusableReason = "age_restricted"
+	TrackPurchaseRequired TrackUnusableReason = "purchase_required"
+	TrackUnavailable      TrackUnusableReason = "unavailable"
+)
+
+func (p *playerResponse) trackUnusableReason() TrackUnusableReason {
+	status := p.PlayabilityStatus
+	for key := range status.ErrorScreen {
+		if strings.Contains(strings.ToLower(key), "ypc") {
+			return TrackPurchaseRequired
+		}
+	}
+	reason := strings.ToLower(status.Reason)
+	switch {
+	case strings.Contains(reason, "purchase") || strings.Contains(reason, "rent"):
+		return TrackPurchaseRequired
+	case status.Status == "LOGIN_REQUIRED" && strings.Contains(reason, "age"):
+		return TrackAgeRestricted
+	case status.Status == "LOGIN_REQUIRED":
+		return TrackLoginRequired
+	}
+	return TrackUnavailable
+}
+
+func (p *playerResponse) annotateTracks() {
+	tracks := p.Captions.PlayerCaptionsTracklistRenderer.CaptionTracks
+	for i := range tracks {
+		tracks[i].Usable = tracks[i].BaseURL.URL != ""
+		tracks[i].UnusableReason = ""
+		if !tracks[i].Usable {
+			tracks[i].UnusableReason = p.trackUnusableReason()
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package caption
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type TrackURL struct {
+	URL string
+
+	client *Client
+}
+
+func (u TrackURL) String() string {
+	return u.URL
+}
+
+func (u TrackURL) MarshalJSON() ([]byte, error) {
+	return json.Marshal(u.URL)
+}
+
+func (u *TrackURL) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &u.URL)
+}
+
+func (u TrackURL) ExpiresAt() time.Time {
+	parsed, err := url.Parse(u.URL)
+	if err != nil {
+		return time.Time{}
+	}
+	sec, err := strconv.ParseInt(parsed.Query().Get("expire"), 10, 64)
+	if err != nil || sec <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0)
+}
+
+func (u TrackURL) clock() Clock {
+	if u.client != nil {
+		return u.client.opts.clock()
+	}
+	return SystemClock
+}
+
+func (u TrackURL) IsExpired() bool {
+	expires := u.ExpiresAt()
+	return !expires.IsZero() && !u.clock().Now().Before(expires)
+}
+
+func (u *TrackURL) Refresh(ctx context.Context) error {
+	parsed, err := url.Parse(u.URL)
+	if err != nil {
+		return fmt.Errorf("failed to parse track URL: %w", err)
+	}
+	query := parsed.Query()
+	videoID := query.Get("v")
+	if err = validateVideoID(videoID); err != nil {
+		return err
+	}
+
+	client := u.client
+	if client == nil {
+		client = NewClient(DefaultOptions())
+	}
+	playerResp, err := client.fetchPlayer(ctx, videoID)
+	if err != nil {
+		return fmt.Errorf("failed to refresh track URL: %w", err)
+	}
+	tracks, err := extractCaptionTracks(playerResp, client.opts.Region)
+	if err != nil {
+		return fmt.Errorf("failed to refresh track URL: %w", err)
+	}
+	for _, track := range tracks {
+		fresh, err := url.Parse(track.BaseURL.URL)
+		if err != nil {
+			continue
+		}
+		if freshQuery := fresh.Query(); freshQuery.Get("lang") == query.Get("lang") && freshQuery.Get("kind") == query.Get("kind") {
+			u.URL = track.BaseURL.URL
+			return nil
+		}
+	}
+	return &NoCaptionsError{VideoID: videoID, Language: query.Get("lang"), Tracks: tracks}
+}
+
+func (p *playerResponse) bindTrackURLs(c *Client) {
+	tracks := p.Captions.PlayerCaptionsTracklistRenderer.CaptionTracks
+	for i := range tracks {
+		tracks[i].BaseURL.client = c
+	}
+}
@@ -0,0 +1,129 @@
+package caption
+
+import (
+	"math"
+	"sort"
+	"time"
+	"unicode/utf8"
+)
+
+func subtitleToEvent(sub SubtitleText) CaptionEvent {
+	startMs := int(math.Round(sub.StartTime * 1000))
+	endMs := int(math.Round(sub.EndTime * 1000))
+	event := CaptionEvent{TStartMs: startMs}
+	if len(sub.Spans) == 0 {
+		event.Segments = []CaptionSegment{{UTF8: sub.Text}}
+	}
+	for _, span := range sub.Spans {
+		if span.Ruby == "" {
+			event.Segments = append(event.Segments, CaptionSegment{UTF8: span.Text, PenID: int(span.Style)})
+			continue
+		}
+		event.Segments = append(event.Segments,
+			CaptionSegment{UTF8: span.Text, PenID: int(span.Style) + rubyBasePenOffset},
+			CaptionSegment{UTF8: span.Ruby, PenID: rubyTextPen})
+	}
+	if endMs > startMs {
+		event.Segments = append(event.Segments, CaptionSegment{TOffsetMs: endMs - startMs})
+	}
+	return event
+}
+
+func (c *Caption) withSubtitles(subs []SubtitleText) *Caption {
+	result := &Caption{
+		Events:   make([]CaptionEvent, 0, len(subs)),
+		VideoID:  c.VideoID,
+		Video:    c.Video,
+		Track:    c.Track,
+		Warnings: c.Warnings,
+
+		downloaded: c.downloaded,
+		client:     c.client,
+	}
+	for _, sub := range subs {
+		if len(sub.Spans) > 0 {
+			result.Pens = formattingPens
+		}
+		result.Events = append(result.Events, subtitleToEvent(sub))
+	}
+	return result
+}
+
+func (c *Caption) CollapseDuplicates(window time.Duration) *Caption {
+	var result []SubtitleText
+	for _, sub := range c.GetSubtitleText() {
+		if n := len(result); n > 0 {
+			prev := &result[n-1]
+			if prev.Text == sub.Text && sub.StartTime-prev.EndTime <= window.Seconds() {
+				prev.EndTime = math.Max(prev.EndTime, sub.EndTime)
+				continue
+			}
+		}
+		result = append(result, sub)
+	}
+	return c.withSubtitles(result)
+}
+
+type ReadingSpeedViolation struct {
+	Index int
+	Cue   SubtitleText
+	CPS   float64
+}
+
+func charsPerSecond(sub SubtitleText) float64 {
+	duration := sub.EndTime - sub.StartTime
+	chars := float64(utf8.RuneCountInString(sub.Text))
+	if duration <= 0 {
+		return math.Inf(1)
+	}
+	return chars / duration
+}
+
+func (c *Caption) EnforceReadingSpeed(maxCPS float64) (*Caption, []ReadingSpeedViolation) {
+	subs := c.GetSubtitleText()
+	if maxCPS <= 0 {
+		return c.withSubtitles(subs), nil
+	}
+
+	var violations []ReadingSpeedViolation
+	for i := range subs {
+		sub := &subs[i]
+		if charsPerSecond(*sub) <= maxCPS {
+			continue
+		}
+		required := float64(utf8.RuneCountInString(sub.Text)) / maxCPS
+		end := sub.StartTime + required
+		if i+1 < len(subs) && end > subs[i+1].StartTime {
+			end = math.Max(sub.EndTime, subs[i+1].StartTime)
+		}
+		sub.EndTime = end
+		if cps := charsPerSecond(*sub); cps > maxCPS {
+			violations = append(violations, ReadingSpeedViolation{Index: i, Cue: *sub, CPS: cps})
+		}
+	}
+	return c.withSubtitles(subs), violations
+}
+
+func (c *Caption) Shift(offset time.Duration) *Caption {
+	return c.ShiftRange(0, 0, offset)
+}
+
+func (c *Caption) ShiftRange(start, end, offset time.Duration) *Caption {
+	subs := c.GetSubtitleText()
+	for i := range subs {
+		sub := &subs[i]
+		if sub.StartTime < start.Seconds() || (end > 0 && sub.StartTime >= end.Seconds()) {
+			continue
+		}
+		shift := offset.Seconds()
+		if sub.StartTime+shift < 0 {
+			shift = -sub.StartTime
+		}
+		sub.StartTime += shift
+		sub.EndTime += shift
+	}
+	sort.SliceStable(subs, func(i, j int) bool {
+		return subs[i].StartTime < subs[j].StartTime
+	})
+	return c.withSubtitles(subs)
+}
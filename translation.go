@@ -0,0 +1,245 @@
+package caption
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+const timingNote = "timing"
+
+type xliffDocument struct {
+	XMLName xml.Name  `xml:"urn:oasis:names:tc:xliff:document:1.2 xliff"`
+	Version string    `xml:"version,attr"`
+	File    xliffFile `xml:"file"`
+}
+
+type xliffFile struct {
+	Original       string      `xml:"original,attr"`
+	SourceLanguage string      `xml:"source-language,attr"`
+	TargetLanguage string      `xml:"target-language,attr,omitempty"`
+	Datatype       string      `xml:"datatype,attr"`
+	Units          []xliffUnit `xml:"body>trans-unit"`
+}
+
+type xliffUnit struct {
+	ID     string      `xml:"id,attr"`
+	Source string      `xml:"source"`
+	Target string      `xml:"target,omitempty"`
+	Notes  []xliffNote `xml:"note"`
+}
+
+type xliffNote struct {
+	From string `xml:"from,attr,omitempty"`
+	Text string `xml:",chardata"`
+}
+
+type tmxDocument struct {
+	XMLName xml.Name  `xml:"tmx"`
+	Version string    `xml:"version,attr"`
+	Header  tmxHeader `xml:"header"`
+	Units   []tmxUnit `xml:"body>tu"`
+}
+
+type tmxHeader struct {
+	CreationTool string `xml:"creationtool,attr"`
+	SrcLang      string `xml:"srclang,attr"`
+	AdminLang    string `xml:"adminlang,attr"`
+	SegType      string `xml:"segtype,attr"`
+	DataType     string `xml:"datatype,attr"`
+	OTMF         string `xml:"o-tmf,attr"`
+}
+
+type tmxUnit struct {
+	ID       string       `xml:"tuid,attr"`
+	Props    []tmxProp    `xml:"prop"`
+	Variants []tmxVariant `xml:"tuv"`
+}
+
+type tmxProp struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+type tmxVariant struct {
+	Lang    string `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+	Segment string `xml:"seg"`
+}
+
+func (c *Caption) sourceLanguage() string {
+	if c.Track != nil && c.Track.LanguageCode != "" {
+		return c.Track.LanguageCode
+	}
+	return "en"
+}
+
+func (c *Caption) original() string {
+	if c.VideoID != "" {
+		return "https://youtu.be/" + c.VideoID
+	}
+	return "captions"
+}
+
+func writeXML(w io.Writer, v any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("failed to marshal XML: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func (c *Caption) WriteXLIFF(w io.Writer) error {
+	if c.xliff != nil && len(c.xliff.Units) == len(c.Events) {
+		return writeXML(w, c.parsedXLIFF())
+	}
+	doc := xliffDocument{
+		Version: "1.2",
+		File: xliffFile{
+			Original:       c.original(),
+			SourceLanguage: c.sourceLanguage(),
+			Datatype:       "plaintext",
+		},
+	}
+	for _, sub := range c.GetSubtitleText() {
+		doc.File.Units = append(doc.File.Units, xliffUnit{
+			ID:     sub.ID(c.VideoID),
+			Source: sub.Text,
+			Notes: []xliffNote{{
+				From: timingNote,
+				Text: formatVTTTime(sub.StartDuration()) + " --> " + formatVTTTime(sub.EndDuration()),
+			}},
+		})
+	}
+	return writeXML(w, doc)
+}
+
+func (c *Caption) parsedXLIFF() xliffDocument {
+	doc := xliffDocument{Version: "1.2", File: *c.xliff}
+	doc.File.Units = nil
+	for i, event := range c.Events {
+		sub, ok := eventToSubtitle(event, c.Pens)
+		if !ok {
+			continue
+		}
+		unit := c.xliff.Units[i]
+		if unit.Target != "" || sub.Text != strings.TrimSpace(unit.Source) {
+			unit.Target = sub.Text
+		}
+		unit.Notes = slices.Clone(unit.Notes)
+		for j, note := range unit.Notes {
+			if note.From == timingNote {
+				unit.Notes[j].Text = formatVTTTime(sub.StartDuration()) + " --> " + formatVTTTime(sub.EndDuration())
+			}
+		}
+		doc.File.Units = append(doc.File.Units, unit)
+	}
+	return doc
+}
+
+func (c *Caption) WriteTMX(w io.Writer) error {
+	lang := c.sourceLanguage()
+	doc := tmxDocument{
+		Version: "1.4",
+		Header: tmxHeader{
+			CreationTool: "youtube-caption",
+			SrcLang:      lang,
+			AdminLang:    "en",
+			SegType:      "block",
+			DataType:     "plaintext",
+			OTMF:         "youtube-caption",
+		},
+	}
+	for _, sub := range c.GetSubtitleText() {
+		doc.Units = append(doc.Units, tmxUnit{
+			ID: sub.ID(c.VideoID),
+			Props: []tmxProp{
+				{Type: "x-start", Value: formatVTTTime(sub.StartDuration())},
+				{Type: "x-end", Value: formatVTTTime(sub.EndDuration())},
+			},
+			Variants: []tmxVariant{{Lang: lang, Segment: sub.Text}},
+		})
+	}
+	return writeXML(w, doc)
+}
+
+func (c *Caption) SaveXLIFF(filename string) error {
+	return writeFileWith(filename, nil, c.WriteXLIFF)
+}
+
+func (c *Caption) SaveTMX(filename string) error {
+	return writeFileWith(filename, nil, c.WriteTMX)
+}
+
+func ParseXLIFF(r io.Reader) (*Caption, error) {
+	var doc xliffDocument
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse XLIFF: %w", err)
+	}
+	var subs []SubtitleText
+	var units []xliffUnit
+	for _, unit := range doc.File.Units {
+		text := unit.Target
+		if strings.TrimSpace(text) == "" {
+			text = unit.Source
+		}
+		for _, note := range unit.Notes {
+			if note.From != timingNote {
+				continue
+			}
+			if start, end, ok := parseTimingLine(note.Text); ok {
+				subs = append(subs, SubtitleText{StartTime: start, EndTime: end, Text: strings.TrimSpace(text)})
+				units = append(units, unit)
+			}
+		}
+	}
+	lang := doc.File.TargetLanguage
+	if lang == "" {
+		lang = doc.File.SourceLanguage
+	}
+	c := (&Caption{Track: &CaptionTrack{LanguageCode: lang}}).withSubtitles(subs)
+	c.xliff = &doc.File
+	c.xliff.Units = units
+	return c, nil
+}
+
+func ParseTMX(r io.Reader, lang string) (*Caption, error) {
+	var doc tmxDocument
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse TMX: %w", err)
+	}
+	if lang == "" {
+		lang = doc.Header.SrcLang
+	}
+	var subs []SubtitleText
+	for _, unit := range doc.Units {
+		var sub SubtitleText
+		var err error
+		for _, prop := range unit.Props {
+			switch prop.Type {
+			case "x-start":
+				sub.StartTime, err = parseTimecode(prop.Value)
+			case "x-end":
+				sub.EndTime, err = parseTimecode(prop.Value)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse TMX unit %s: %w", unit.ID, err)
+			}
+		}
+		for _, variant := range unit.Variants {
+			if strings.EqualFold(variant.Lang, lang) {
+				sub.Text = strings.TrimSpace(variant.Segment)
+			}
+		}
+		if sub.Text != "" {
+			subs = append(subs, sub)
+		}
+	}
+	return (&Caption{Track: &CaptionTrack{LanguageCode: lang}}).withSubtitles(subs), nil
+}
@@ -0,0 +1,69 @@
+package caption
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+func ttmlText(text string) string {
+	return strings.ReplaceAll(xmlEscape(text), "&#xA;", "<br/>")
+}
+
+func ttmlSpans(spans []StyledSpan) string {
+	var result strings.Builder
+	for _, span := range spans {
+		text := ttmlText(span.Text)
+		if span.Ruby != "" {
+			text = fmt.Sprintf(`<span tts:ruby="container"><span tts:ruby="base">%s</span><span tts:ruby="text">%s</span></span>`,
+				text, ttmlText(span.Ruby))
+		}
+		var attrs []string
+		if span.Style&StyleBold != 0 {
+			attrs = append(attrs, `tts:fontWeight="bold"`)
+		}
+		if span.Style&StyleItalic != 0 {
+			attrs = append(attrs, `tts:fontStyle="italic"`)
+		}
+		if len(attrs) > 0 {
+			text = fmt.Sprintf("<span %s>%s</span>", strings.Join(attrs, " "), text)
+		}
+		result.WriteString(text)
+	}
+	return result.String()
+}
+
+func (c *Caption) GetTTML() string {
+	lang := "en"
+	if c.Track != nil && c.Track.LanguageCode != "" {
+		lang = c.Track.LanguageCode
+	}
+
+	var result strings.Builder
+	result.WriteString("<?xml version=\"1.0\" encoding=\"utf-8\"?>\n")
+	result.WriteString(fmt.Sprintf("<tt xmlns=\"http://www.w3.org/ns/ttml\" xmlns:tts=\"http://www.w3.org/ns/ttml#styling\" xml:lang=\"%s\">\n", xmlEscape(lang)))
+	result.WriteString("<body>\n<div>\n")
+	for _, sub := range c.GetSubtitleText() {
+		text := ttmlText(sub.Text)
+		if len(sub.Spans) > 0 {
+			text = ttmlSpans(sub.Spans)
+		}
+		result.WriteString(fmt.Sprintf("<p begin=\"%s\" end=\"%s\">%s</p>\n",
+			formatTTMLTime(sub.StartDuration()),
+			formatTTMLTime(sub.EndDuration()),
+			text))
+	}
+	result.WriteString("</div>\n</body>\n</tt>\n")
+	return result.String()
+}
+
+func (c *Caption) SaveTTML(filename string) error {
+	return os.WriteFile(filename, []byte(c.GetTTML()), 0644)
+}
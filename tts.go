@@ -0,0 +1,152 @@
+package caption
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	ttsNumberRegex  = regexp.MustCompile(`\$?\d[\d,]*(?:\.\d+)?%?`)
+	ttsAcronymRegex = regexp.MustCompile(`\b[A-Z]{2,5}\b`)
+	ttsWordRegex    = regexp.MustCompile(`\b[A-Za-z][A-Za-z.]*\.?`)
+)
+
+var ttsAbbreviations = map[string]string{
+	"Dr.":     "Doctor",
+	"Mr.":     "Mister",
+	"Mrs.":    "Missus",
+	"Ms.":     "Miz",
+	"St.":     "Saint",
+	"vs.":     "versus",
+	"vs":      "versus",
+	"etc.":    "et cetera",
+	"e.g.":    "for example",
+	"i.e.":    "that is",
+	"approx.": "approximately",
+	"min.":    "minutes",
+	"hr.":     "hour",
+	"No.":     "number",
+	"Jan.":    "January",
+	"Feb.":    "February",
+	"Aug.":    "August",
+	"Sept.":   "September",
+	"Oct.":    "October",
+	"Nov.":    "November",
+	"Dec.":    "December",
+}
+
+var ttsSpokenAcronyms = map[string]bool{
+	"NASA": true, "NATO": true, "UNESCO": true, "LASER": true, "SCUBA": true, "GIF": true,
+	"JPEG": true, "COVID": true, "RAM": true, "ROM": true,
+}
+
+var (
+	ttsOnes = []string{"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+		"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen"}
+	ttsTens   = []string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+	ttsScales = []struct {
+		value int64
+		name  string
+	}{{1_000_000_000_000, "trillion"}, {1_000_000_000, "billion"}, {1_000_000, "million"}, {1_000, "thousand"}}
+)
+
+func spellInteger(n int64) string {
+	if n < 0 {
+		return "minus " + spellInteger(-n)
+	}
+	if n < 20 {
+		return ttsOnes[n]
+	}
+	if n < 100 {
+		if n%10 == 0 {
+			return ttsTens[n/10]
+		}
+		return ttsTens[n/10] + "-" + ttsOnes[n%10]
+	}
+	if n < 1000 {
+		result := ttsOnes[n/100] + " hundred"
+		if n%100 != 0 {
+			result += " " + spellInteger(n%100)
+		}
+		return result
+	}
+	for _, scale := range ttsScales {
+		if n >= scale.value {
+			result := spellInteger(n/scale.value) + " " + scale.name
+			if n%scale.value != 0 {
+				result += " " + spellInteger(n%scale.value)
+			}
+			return result
+		}
+	}
+	return strconv.FormatInt(n, 10)
+}
+
+func spellNumber(token string) string {
+	currency := strings.HasPrefix(token, "$")
+	percent := strings.HasSuffix(token, "%")
+	digits := strings.ReplaceAll(strings.TrimSuffix(strings.TrimPrefix(token, "$"), "%"), ",", "")
+
+	whole, fraction, _ := strings.Cut(digits, ".")
+	n, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return token
+	}
+	words := spellInteger(n)
+	if fraction != "" {
+		if currency && len(fraction) == 2 {
+			cents, _ := strconv.ParseInt(fraction, 10, 64)
+			return words + " dollars and " + spellInteger(cents) + " cents"
+		}
+		parts := make([]string, len(fraction))
+		for i, d := range fraction {
+			parts[i] = ttsOnes[d-'0']
+		}
+		words += " point " + strings.Join(parts, " ")
+	}
+	switch {
+	case currency && n == 1 && fraction == "":
+		words += " dollar"
+	case currency:
+		words += " dollars"
+	case percent:
+		words += " percent"
+	}
+	return words
+}
+
+func spellAcronym(acronym string) string {
+	if ttsSpokenAcronyms[acronym] {
+		return acronym
+	}
+	return strings.Join(strings.Split(acronym, ""), " ")
+}
+
+func NormalizeForTTS(text string) string {
+	text = ttsWordRegex.ReplaceAllStringFunc(text, func(word string) string {
+		if expansion, ok := ttsAbbreviations[word]; ok {
+			return expansion
+		}
+		return word
+	})
+	text = ttsNumberRegex.ReplaceAllStringFunc(text, spellNumber)
+	text = strings.ReplaceAll(text, "&", " and ")
+	text = ttsAcronymRegex.ReplaceAllStringFunc(text, spellAcronym)
+	return strings.Join(strings.Fields(text), " ")
+}
+
+func (c *Caption) NormalizeForTTS() *Caption {
+	subs := c.GetSubtitleText()
+	for i := range subs {
+		lines := strings.Split(subs[i].Text, "\n")
+		for j, line := range lines {
+			lines[j] = NormalizeForTTS(line)
+		}
+		if text := strings.Join(lines, "\n"); text != subs[i].Text {
+			subs[i].Text = text
+			subs[i].Spans = nil
+		}
+	}
+	return c.withSubtitles(subs)
+}
@@ -0,0 +1,101 @@
+package caption
+
+import (
+	"context"
+	"math"
+)
+
+type CueChange struct {
+	Old SubtitleText
+	New SubtitleText
+}
+
+type Changeset struct {
+	Added    []SubtitleText
+	Removed  []SubtitleText
+	Modified []CueChange
+	Caption  *Caption
+}
+
+func (cs *Changeset) Changed() bool {
+	return len(cs.Added) > 0 || len(cs.Removed) > 0 || len(cs.Modified) > 0
+}
+
+func sameCue(a, b SubtitleText) bool {
+	return a.Text == b.Text &&
+		math.Round(a.StartTime*1000) == math.Round(b.StartTime*1000) &&
+		math.Round(a.EndTime*1000) == math.Round(b.EndTime*1000)
+}
+
+func Diff(previous, current *Caption) *Changeset {
+	cs := &Changeset{Caption: current}
+	var oldSubs, newSubs []SubtitleText
+	if previous != nil {
+		oldSubs = previous.GetSubtitleText()
+	}
+	if current != nil {
+		newSubs = current.GetSubtitleText()
+	}
+
+	oldByID := make(map[string][]int, len(oldSubs))
+	for i, sub := range oldSubs {
+		id := sub.ID("")
+		oldByID[id] = append(oldByID[id], i)
+	}
+	matched := make([]bool, len(oldSubs))
+	var added []SubtitleText
+	for _, sub := range newSubs {
+		id := sub.ID("")
+		if indexes := oldByID[id]; len(indexes) > 0 {
+			i := indexes[0]
+			oldByID[id] = indexes[1:]
+			matched[i] = true
+			if !sameCue(oldSubs[i], sub) {
+				cs.Modified = append(cs.Modified, CueChange{Old: oldSubs[i], New: sub})
+			}
+			continue
+		}
+		added = append(added, sub)
+	}
+
+	removedByStart := make(map[int64][]SubtitleText)
+	var removedOrder []int64
+	for i, sub := range oldSubs {
+		if matched[i] {
+			continue
+		}
+		start := int64(math.Round(sub.StartTime * 1000))
+		if len(removedByStart[start]) == 0 {
+			removedOrder = append(removedOrder, start)
+		}
+		removedByStart[start] = append(removedByStart[start], sub)
+	}
+	for _, sub := range added {
+		start := int64(math.Round(sub.StartTime * 1000))
+		if matches := removedByStart[start]; len(matches) > 0 {
+			cs.Modified = append(cs.Modified, CueChange{Old: matches[0], New: sub})
+			removedByStart[start] = matches[1:]
+			continue
+		}
+		cs.Added = append(cs.Added, sub)
+	}
+	for _, start := range removedOrder {
+		cs.Removed = append(cs.Removed, removedByStart[start]...)
+	}
+	return cs
+}
+
+func CheckForUpdates(ctx context.Context, videoID string, previous *Caption, opts *Options) (*Changeset, error) {
+	return NewClient(opts).CheckForUpdates(ctx, videoID, previous)
+}
+
+func (c *Client) CheckForUpdates(ctx context.Context, videoID string, previous *Caption) (*Changeset, error) {
+	if err := validateVideoID(videoID); err != nil {
+		return nil, err
+	}
+	current, err := c.fetch(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+	return Diff(previous, current), nil
+}
@@ -0,0 +1,63 @@
+package caption
+
+import (
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+type Usage struct {
+	Requests    int64     `json:"requests"`
+	Retries     int64     `json:"retries"`
+	RateLimited int64     `json:"rateLimited"`
+	Errors      int64     `json:"errors"`
+	Bytes       int64     `json:"bytes"`
+	Since       time.Time `json:"since"`
+}
+
+func (u Usage) Sub(prev Usage) Usage {
+	return Usage{
+		Requests:    u.Requests - prev.Requests,
+		Retries:     u.Retries - prev.Retries,
+		RateLimited: u.RateLimited - prev.RateLimited,
+		Errors:      u.Errors - prev.Errors,
+		Bytes:       u.Bytes - prev.Bytes,
+		Since:       prev.Since,
+	}
+}
+
+type usageCounter struct {
+	requests    atomic.Int64
+	retries     atomic.Int64
+	rateLimited atomic.Int64
+	errors      atomic.Int64
+	bytes       atomic.Int64
+	since       time.Time
+}
+
+func newUsageCounter(clock Clock) *usageCounter {
+	return &usageCounter{since: clock.Now()}
+}
+
+func (c *Client) Snapshot() Usage {
+	u := c.usage
+	return Usage{
+		Requests:    u.requests.Load(),
+		Retries:     u.retries.Load(),
+		RateLimited: u.rateLimited.Load(),
+		Errors:      u.errors.Load(),
+		Bytes:       u.bytes.Load(),
+		Since:       u.since,
+	}
+}
+
+type usageBody struct {
+	io.ReadCloser
+	bytes *atomic.Int64
+}
+
+func (b *usageBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.bytes.Add(int64(n))
+	return n, err
+}
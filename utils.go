@@ -3,41 +3,82 @@ package caption
 import (
 	"encoding/json"
 	"fmt"
+	"iter"
 	"os"
 	"sort"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
-func (c *Caption) GetSubtitleText() []SubtitleText {
-	var result []SubtitleText
-	for _, event := range c.Events {
-		if len(event.Segments) == 0 {
-			continue
+func eventToSubtitle(event CaptionEvent, pens []CaptionPen) (SubtitleText, bool) {
+	if len(event.Segments) == 0 {
+		return SubtitleText{}, false
+	}
+
+	var text strings.Builder
+	var spans []StyledSpan
+	baseSpan := -1
+	startTime := float64(event.TStartMs) / 1000.0
+	endTime := startTime
+
+	for _, seg := range event.Segments {
+		if seg.UTF8 != "\n" {
+			penID := seg.PenID
+			if penID == 0 {
+				penID = event.PenID
+			}
+			pen := penFor(pens, penID)
+			switch pen.Ruby {
+			case rubyParen:
+			case rubyBefore, rubyAfter:
+				if n := len(spans); n > 0 && baseSpan == n-1 {
+					spans[n-1].Ruby += seg.UTF8
+				}
+			case rubyBase:
+				text.WriteString(seg.UTF8)
+				spans = append(spans, StyledSpan{Text: seg.UTF8, Style: pen.style()})
+				baseSpan = len(spans) - 1
+			default:
+				text.WriteString(seg.UTF8)
+				spans = appendSpan(spans, seg.UTF8, pen.style())
+			}
+			segEndTime := float64(event.TStartMs+seg.TOffsetMs) / 1000.0
+			if segEndTime > endTime {
+				endTime = segEndTime
+			}
 		}
+	}
 
-		var text strings.Builder
-		startTime := float64(event.TStartMs) / 1000.0
-		endTime := startTime
+	textStr := strings.TrimSpace(text.String())
+	if textStr == "" {
+		return SubtitleText{}, false
+	}
+	return SubtitleText{
+		StartTime: startTime,
+		EndTime:   endTime,
+		Text:      textStr,
+		Spans:     trimSpans(spans),
+	}, true
+}
 
-		for _, seg := range event.Segments {
-			if seg.UTF8 != "\n" {
-				text.WriteString(seg.UTF8)
-				segEndTime := float64(event.TStartMs+seg.TOffsetMs) / 1000.0
-				if segEndTime > endTime {
-					endTime = segEndTime
+func (c *Caption) Cues() iter.Seq[SubtitleText] {
+	return func(yield func(SubtitleText) bool) {
+		for _, event := range c.Events {
+			if sub, ok := eventToSubtitle(event, c.Pens); ok {
+				if !yield(sub) {
+					return
 				}
 			}
 		}
+	}
+}
 
-		textStr := strings.TrimSpace(text.String())
-		if textStr != "" {
-			result = append(result, SubtitleText{
-				StartTime: startTime,
-				EndTime:   endTime,
-				Text:      textStr,
-			})
-		}
+func (c *Caption) GetSubtitleText() []SubtitleText {
+	var result []SubtitleText
+	for sub := range c.Cues() {
+		result = append(result, sub)
 	}
 
 	sort.Slice(result, func(i, j int) bool {
@@ -47,6 +88,12 @@ func (c *Caption) GetSubtitleText() []SubtitleText {
 	return result
 }
 
+type PlainTextOptions struct {
+	Separator         string
+	RepairHyphenation bool
+	PreserveNewlines  bool
+}
+
 func (c *Caption) GetPlainText() string {
 	subtitles := c.GetSubtitleText()
 	var result strings.Builder
@@ -59,6 +106,56 @@ func (c *Caption) GetPlainText() string {
 	return strings.TrimSpace(result.String())
 }
 
+func (c *Caption) GetPlainTextWithOptions(opts *PlainTextOptions) string {
+	if opts == nil {
+		return c.GetPlainText()
+	}
+	separator := opts.Separator
+	if separator == "" {
+		separator = " "
+	}
+
+	var pieces []string
+	var breaks []string
+	for _, sub := range c.GetSubtitleText() {
+		for i, line := range strings.Split(sub.Text, "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" {
+				continue
+			}
+			if len(pieces) > 0 {
+				if i > 0 && opts.PreserveNewlines {
+					breaks = append(breaks, "\n")
+				} else {
+					breaks = append(breaks, separator)
+				}
+			}
+			pieces = append(pieces, line)
+		}
+	}
+
+	var result strings.Builder
+	for i, piece := range pieces {
+		if i > 0 && !(opts.RepairHyphenation && wrappedHyphen(pieces[i-1], piece)) {
+			result.WriteString(breaks[i-1])
+		}
+		if opts.RepairHyphenation && i+1 < len(pieces) && wrappedHyphen(piece, pieces[i+1]) {
+			piece = strings.TrimSuffix(piece, "-")
+		}
+		result.WriteString(piece)
+	}
+	return result.String()
+}
+
+func wrappedHyphen(prev, next string) bool {
+	if len(prev) < 2 || !strings.HasSuffix(prev, "-") || strings.HasSuffix(prev, "--") {
+		return false
+	}
+	before, _ := utf8.DecodeLastRuneInString(strings.TrimSuffix(prev, "-"))
+	after, _ := utf8.DecodeRuneInString(next)
+	return unicode.IsLetter(before) && unicode.IsLower(after)
+}
+
 func (c *Caption) GetSRT() string {
 	subtitles := c.GetSubtitleText()
 	var result strings.Builder
@@ -66,9 +163,9 @@ func (c *Caption) GetSRT() string {
 	for i, sub := range subtitles {
 		result.WriteString(fmt.Sprintf("%d\n", i+1))
 		result.WriteString(fmt.Sprintf("%s --> %s\n",
-			formatSRTTime(sub.StartTime),
-			formatSRTTime(sub.EndTime)))
-		result.WriteString(sub.Text)
+			formatSRTTime(sub.StartDuration()),
+			formatSRTTime(sub.EndDuration())))
+		result.WriteString(sub.Markup())
 		result.WriteString("\n\n")
 	}
 
@@ -76,16 +173,24 @@ func (c *Caption) GetSRT() string {
 }
 
 func (c *Caption) GetVTT() string {
+	return c.GetVTTWithOptions(nil)
+}
+
+func (c *Caption) getVTT(cueIDs bool) string {
 	subtitles := c.GetSubtitleText()
 	var result strings.Builder
 
 	result.WriteString("WEBVTT\n\n")
 
 	for _, sub := range subtitles {
+		if cueIDs {
+			result.WriteString(sub.ID(c.VideoID))
+			result.WriteString("\n")
+		}
 		result.WriteString(fmt.Sprintf("%s --> %s\n",
-			formatVTTTime(sub.StartTime),
-			formatVTTTime(sub.EndTime)))
-		result.WriteString(sub.Text)
+			formatVTTTime(sub.StartDuration()),
+			formatVTTTime(sub.EndDuration())))
+		result.WriteString(sub.vttMarkup())
 		result.WriteString("\n\n")
 	}
 
@@ -112,24 +217,30 @@ func (c *Caption) SavePlainText(filename string) error {
 	return os.WriteFile(filename, []byte(c.GetPlainText()), 0644)
 }
 
-func formatSRTTime(seconds float64) string {
-	t := time.Duration(seconds * float64(time.Second))
-	hours := int(t.Hours())
-	minutes := int(t.Minutes()) % 60
-	secs := int(t.Seconds()) % 60
-	millis := int(t.Nanoseconds()/1000000) % 1000
-	return fmt.Sprintf("%02d:%02d:%02d,%03d", hours, minutes, secs, millis)
+func (c *Caption) SaveMarkdown(filename string) error {
+	return os.WriteFile(filename, []byte(c.GetMarkdown()), 0644)
 }
 
-func formatVTTTime(seconds float64) string {
-	t := time.Duration(seconds * float64(time.Second))
-	hours := int(t.Hours())
-	minutes := int(t.Minutes()) % 60
-	secs := int(t.Seconds()) % 60
-	millis := int(t.Nanoseconds()/1000000) % 1000
-	return fmt.Sprintf("%02d:%02d:%02d.%03d", hours, minutes, secs, millis)
+func (c *Caption) SaveHTML(filename string) error {
+	return os.WriteFile(filename, []byte(c.GetHTML()), 0644)
+}
+
+func (s SubtitleText) StartDuration() time.Duration {
+	return secondsToDuration(s.StartTime)
+}
+
+func (s SubtitleText) EndDuration() time.Duration {
+	return secondsToDuration(s.EndTime)
+}
+
+func (s SubtitleText) Duration() time.Duration {
+	return s.EndDuration() - s.StartDuration()
 }
 
 func (ct *CaptionTrack) String() string {
-	return fmt.Sprintf("%s (%s) - %s", ct.Name.SimpleText, ct.LanguageCode, ct.Kind)
+	s := fmt.Sprintf("%s (%s) - %s", ct.Name.SimpleText, ct.LanguageCode, ct.Kind)
+	if ct.IsForced() && !strings.Contains(strings.ToLower(ct.Name.SimpleText), "forced") {
+		s += " [forced]"
+	}
+	return s
 }
@@ -0,0 +1,93 @@
+package caption
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sync"
+)
+
+const webConfigURL = "https://www.youtube.com/?hl=en"
+
+var (
+	ErrVersionNotFound = errors.New("client version not found in web player config")
+
+	clientVersionRegex = regexp.MustCompile(`"INNERTUBE_CLIENT_VERSION":"([0-9][0-9.]*)"`)
+)
+
+type versionCache struct {
+	mu       sync.Mutex
+	versions map[string]string
+	tried    map[string]bool
+}
+
+func newVersionCache() *versionCache {
+	return &versionCache{versions: make(map[string]string), tried: make(map[string]bool)}
+}
+
+func (c *Client) DiscoverClientVersion(ctx context.Context) (string, error) {
+	req, err := http.NewRequest("GET", webConfigURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", c.opts.userAgentFor(WebClient))
+	req.Header.Set("Accept-Language", "en")
+
+	resp, err := c.do(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch web player config: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := c.opts.readLimited(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read web player config: %w", err)
+	}
+	m := clientVersionRegex.FindSubmatch(body)
+	if m == nil {
+		return "", ErrVersionNotFound
+	}
+
+	version := string(m[1])
+	c.versions.mu.Lock()
+	c.versions.versions[ClientNameWeb] = version
+	c.versions.tried[ClientNameWeb] = true
+	c.versions.mu.Unlock()
+	return version, nil
+}
+
+func (c *Client) clientVersion(ctx context.Context, client InnerTubeClient) InnerTubeClient {
+	if client.Name != ClientNameWeb {
+		return client
+	}
+	c.versions.mu.Lock()
+	version, tried := c.versions.versions[client.Name], c.versions.tried[client.Name]
+	c.versions.mu.Unlock()
+	if !tried && c.opts.DiscoverVersions {
+		c.versions.mu.Lock()
+		c.versions.tried[client.Name] = true
+		c.versions.mu.Unlock()
+		version, _ = c.DiscoverClientVersion(ctx)
+	}
+	if version != "" {
+		client.Version = version
+	}
+	return client
+}
+
+func (c *Client) requestPlayerAs(ctx context.Context, videoID string, client InnerTubeClient) (*playerResponse, error) {
+	client = c.clientVersion(ctx, client)
+	playerResp, err := c.requestPlayerWith(ctx, videoID, client)
+	var httpErr *HTTPError
+	if err == nil || !c.opts.DiscoverVersions || client.Name != ClientNameWeb ||
+		!errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusBadRequest {
+		return playerResp, err
+	}
+	version, discoverErr := c.DiscoverClientVersion(ctx)
+	if discoverErr != nil || version == client.Version {
+		return nil, err
+	}
+	client.Version = version
+	return c.requestPlayerWith(ctx, videoID, client)
+}
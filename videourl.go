@@ -0,0 +1,122 @@
+package caption
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timestampRegex = regexp.MustCompile(`^(?:(\d+)h)?(?:(\d+)m)?(?:(\d+)s?)?$`)
+
+type VideoURL struct {
+	VideoID string
+	Start   time.Duration
+}
+
+func parseTimestamp(s string) time.Duration {
+	m := timestampRegex.FindStringSubmatch(strings.ToLower(strings.TrimSpace(s)))
+	if m == nil {
+		return 0
+	}
+	var total time.Duration
+	for i, unit := range []time.Duration{time.Hour, time.Minute, time.Second} {
+		if m[i+1] != "" {
+			n, _ := strconv.Atoi(m[i+1])
+			total += time.Duration(n) * unit
+		}
+	}
+	return total
+}
+
+func ParseVideoURL(s string) (*VideoURL, error) {
+	s = strings.TrimSpace(s)
+	if videoIDRegex.MatchString(s) {
+		return &VideoURL{VideoID: s}, nil
+	}
+	if !strings.Contains(s, "://") {
+		s = "https://" + s
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, ErrInvalidVideoID
+	}
+
+	var id string
+	query := u.Query()
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	host = strings.TrimPrefix(host, "m.")
+	switch host {
+	case "youtu.be":
+		id = strings.Trim(u.Path, "/")
+	case "youtube.com", "music.youtube.com", "youtube-nocookie.com":
+		if v := query.Get("v"); v != "" {
+			id = v
+			break
+		}
+		parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+		if len(parts) == 2 {
+			switch parts[0] {
+			case "shorts", "embed", "live", "v", "e":
+				id = parts[1]
+			}
+		}
+	}
+
+	if err = validateVideoID(id); err != nil {
+		return nil, err
+	}
+
+	result := &VideoURL{VideoID: id}
+	for _, t := range []string{query.Get("t"), query.Get("start"), strings.TrimPrefix(u.Fragment, "t=")} {
+		if t != "" {
+			if result.Start = parseTimestamp(t); result.Start > 0 {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func ExtractVideoID(s string) (string, error) {
+	u, err := ParseVideoURL(s)
+	if err != nil {
+		return "", err
+	}
+	return u.VideoID, nil
+}
+
+func (c *Caption) Slice(start, end time.Duration) *Caption {
+	var subs []SubtitleText
+	for _, sub := range c.GetSubtitleText() {
+		if sub.EndTime < start.Seconds() {
+			continue
+		}
+		if end > 0 && sub.StartTime >= end.Seconds() {
+			continue
+		}
+		subs = append(subs, sub)
+	}
+	return c.withSubtitles(subs)
+}
+
+func DownloadURL(ctx context.Context, rawURL string, opts *Options) (*Caption, time.Duration, error) {
+	return NewClient(opts).DownloadURL(ctx, rawURL)
+}
+
+func (c *Client) DownloadURL(ctx context.Context, rawURL string) (*Caption, time.Duration, error) {
+	u, err := ParseVideoURL(rawURL)
+	if err != nil {
+		return nil, 0, err
+	}
+	caption, err := c.Download(ctx, u.VideoID)
+	if err != nil {
+		return nil, u.Start, err
+	}
+	if c.opts.SliceFromTimestamp && u.Start > 0 {
+		caption = caption.Slice(u.Start, 0)
+	}
+	return caption, u.Start, nil
+}
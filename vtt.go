@@ -0,0 +1,98 @@
+package caption
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type VTTOptions struct {
+	Metadata bool
+	CueIDs   bool
+}
+
+type CueWord struct {
+	Text       string  `json:"text"`
+	Start      float64 `json:"start"`
+	Confidence int     `json:"confidence"`
+}
+
+type cuePayload struct {
+	Text       string    `json:"text"`
+	Confidence float64   `json:"confidence"`
+	Words      []CueWord `json:"words,omitempty"`
+}
+
+func eventWords(event CaptionEvent) []CueWord {
+	var words []CueWord
+	for _, seg := range event.Segments {
+		text := strings.TrimSpace(seg.UTF8)
+		if text == "" {
+			continue
+		}
+		words = append(words, CueWord{
+			Text:       text,
+			Start:      float64(event.TStartMs+seg.TOffsetMs) / 1000.0,
+			Confidence: seg.AcAsrConf,
+		})
+	}
+	return words
+}
+
+func (c *Caption) GetVTTWithOptions(opts *VTTOptions) string {
+	if opts == nil {
+		opts = &VTTOptions{}
+	}
+	if !opts.Metadata {
+		return c.getVTT(opts.CueIDs)
+	}
+
+	type metadataCue struct {
+		sub     SubtitleText
+		payload cuePayload
+	}
+	var cues []metadataCue
+	for _, event := range c.Events {
+		sub, ok := eventToSubtitle(event, c.Pens)
+		if !ok {
+			continue
+		}
+		words := eventWords(event)
+		var confidence float64
+		for _, w := range words {
+			confidence += float64(w.Confidence)
+		}
+		if len(words) > 0 {
+			confidence /= float64(len(words))
+		}
+		cues = append(cues, metadataCue{sub, cuePayload{Text: sub.Text, Confidence: confidence, Words: words}})
+	}
+	sort.SliceStable(cues, func(i, j int) bool {
+		return cues[i].sub.StartTime < cues[j].sub.StartTime
+	})
+
+	var result strings.Builder
+	result.WriteString("WEBVTT - metadata\n\n")
+	for i, cue := range cues {
+		payload, _ := json.Marshal(cue.payload)
+		if opts.CueIDs {
+			result.WriteString(cue.sub.ID(c.VideoID))
+		} else {
+			result.WriteString(strconv.Itoa(i + 1))
+		}
+		result.WriteString("\n")
+		result.WriteString(fmt.Sprintf("%s --> %s\n",
+			formatVTTTime(cue.sub.StartDuration()),
+			formatVTTTime(cue.sub.EndDuration())))
+		result.Write(payload)
+		result.WriteString("\n\n")
+	}
+	return result.String()
+}
+
+func (c *Caption) SaveVTTWithOptions(filename string, opts *VTTOptions) error {
+	return os.WriteFile(filename, []byte(c.GetVTTWithOptions(opts)), 0644)
+}
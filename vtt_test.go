@@ -0,0 +1,26 @@
+package caption
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGetVTTCueIDs(t *testing.T) {
+	c := (&Caption{VideoID: "abc"}).withSubtitles([]SubtitleText{{StartTime: 1, EndTime: 2, Text: "hello"}})
+	id := c.GetSubtitleText()[0].ID(c.VideoID)
+
+	if got, want := c.GetVTT(), "WEBVTT\n\n00:00:01.000 --> 00:00:02.000\nhello\n\n"; got != want {
+		t.Errorf("GetVTT() = %q, want %q", got, want)
+	}
+	withIDs := c.GetVTTWithOptions(&VTTOptions{CueIDs: true})
+	if want := "WEBVTT\n\n" + id + "\n00:00:01.000 --> 00:00:02.000\nhello\n\n"; withIDs != want {
+		t.Errorf("GetVTTWithOptions(CueIDs) = %q, want %q", withIDs, want)
+	}
+	parsed, err := ParseVTT(strings.NewReader(withIDs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subs := parsed.GetSubtitleText(); len(subs) != 1 || subs[0].Text != "hello" {
+		t.Errorf("cue IDs leaked into parsed text: %+v", subs)
+	}
+}
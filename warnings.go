@@ -0,0 +1,84 @@
+package caption
+
+import "fmt"
+
+type WarningCode string
+
+const (
+	WarnLanguageFallback WarningCode = "language_fallback"
+	WarnKindFallback     WarningCode = "kind_fallback"
+	WarnClientFallback   WarningCode = "client_fallback"
+	WarnEventsSkipped    WarningCode = "events_skipped"
+	WarnTimingCorrected  WarningCode = "timing_corrected"
+)
+
+type Warning struct {
+	Code    WarningCode `json:"code"`
+	Message string      `json:"message"`
+}
+
+func (w Warning) String() string {
+	return string(w.Code) + ": " + w.Message
+}
+
+func (c *Client) warn(videoID string, warnings []Warning) {
+	if c.opts.OnWarning == nil {
+		return
+	}
+	for _, w := range warnings {
+		c.opts.OnWarning(videoID, w)
+	}
+}
+
+func (p *playerResponse) clientWarnings(clients []InnerTubeClient) []Warning {
+	if p.client == "" || len(clients) == 0 || p.client == clients[0].Name {
+		return nil
+	}
+	return []Warning{{
+		Code:    WarnClientFallback,
+		Message: fmt.Sprintf("%s client was unplayable, used %s", clients[0].Name, p.client),
+	}}
+}
+
+func trackWarnings(track *CaptionTrack, opts *Options) []Warning {
+	switch {
+	case opts.Language != "" && track.LanguageCode != opts.Language:
+		return []Warning{{
+			Code:    WarnLanguageFallback,
+			Message: fmt.Sprintf("no %q track, used %q (%s)", opts.Language, track.LanguageCode, kindName(track.Kind)),
+		}}
+	case track.Kind != opts.Kind:
+		return []Warning{{
+			Code:    WarnKindFallback,
+			Message: fmt.Sprintf("no %s %q track, used %s", kindName(opts.Kind), track.LanguageCode, kindName(track.Kind)),
+		}}
+	}
+	return nil
+}
+
+func kindName(kind string) string {
+	if kind == "" {
+		return "manual"
+	}
+	return kind
+}
+
+func skippedWarning(skipped int) []Warning {
+	if skipped == 0 {
+		return nil
+	}
+	return []Warning{{
+		Code:    WarnEventsSkipped,
+		Message: fmt.Sprintf("skipped %d empty or non-speech events", skipped),
+	}}
+}
+
+func correctionWarning(corrections []Correction) []Warning {
+	if len(corrections) == 0 {
+		return nil
+	}
+	return []Warning{{
+		Code:    WarnTimingCorrected,
+		Message: fmt.Sprintf("corrected %d timing issues", len(corrections)),
+	}}
+}